@@ -80,14 +80,31 @@ type Order struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                   // 订单id
-	UserId      int64  `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`          // 用户id
-	Pair        string `protobuf:"bytes,3,opt,name=pair,proto3" json:"pair,omitempty"`               // 交易对
-	Price       string `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`             // 价格
-	Amount      string `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`           // 数量
-	Side        string `protobuf:"bytes,6,opt,name=side,proto3" json:"side,omitempty"`               // 订单方向 buy/sell
-	Type        string `protobuf:"bytes,7,opt,name=type,proto3" json:"type,omitempty"`               // 订单类型 limit/market
-	TimeInForce string `protobuf:"bytes,8,opt,name=timeInForce,proto3" json:"timeInForce,omitempty"` // 订单有效时间,type为limit时才生效 GTC/IOC/FOK
+	Id              string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                            // 订单id
+	UserId          int64  `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`                   // 用户id
+	Pair            string `protobuf:"bytes,3,opt,name=pair,proto3" json:"pair,omitempty"`                        // 交易对
+	Price           string `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`                      // 价格
+	Amount          string `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`                    // 数量
+	Side            string `protobuf:"bytes,6,opt,name=side,proto3" json:"side,omitempty"`                        // 订单方向 buy/sell
+	Type            string `protobuf:"bytes,7,opt,name=type,proto3" json:"type,omitempty"`                        // 订单类型 limit/market/stop_market/stop_limit
+	TimeInForce     string `protobuf:"bytes,8,opt,name=timeInForce,proto3" json:"timeInForce,omitempty"`          // 订单有效时间,type为limit/stop_limit时才生效 GTC/IOC/FOK/GTD
+	TriggerPrice    string `protobuf:"bytes,9,opt,name=triggerPrice,proto3" json:"triggerPrice,omitempty"`        // 触发价，type为stop_market/stop_limit时才生效
+	PostOnly        string `protobuf:"bytes,10,opt,name=postOnly,proto3" json:"postOnly,omitempty"`               // 只做maker reject/reprice，为空时不限制，GTC/GTD限价单才生效
+	Stp             string `protobuf:"bytes,11,opt,name=stp,proto3" json:"stp,omitempty"`                         // 自成交保护 cancel_newest/cancel_oldest/cancel_both/decrement_cancel，为空时允许自成交
+	DisplayAmount   string `protobuf:"bytes,12,opt,name=displayAmount,proto3" json:"displayAmount,omitempty"`     // 冰山单每次显示的数量，为空时不是冰山单，GTC/GTD限价单才生效
+	ExpireTime      int64  `protobuf:"varint,13,opt,name=expireTime,proto3" json:"expireTime,omitempty"`          // 过期时间(毫秒时间戳)，timeInForce为GTD时才生效，到期后自动撤销
+	QuoteAmount     string `protobuf:"bytes,14,opt,name=quoteAmount,proto3" json:"quoteAmount,omitempty"`         // 按计价货币金额下单，type为market时才生效，不为空时amount必须为空
+	ProtectPrice    string `protobuf:"bytes,15,opt,name=protectPrice,proto3" json:"protectPrice,omitempty"`       // 市价单最差成交价，买单不高于、卖单不低于该价格，为空时只按交易对的价格保护范围限制
+	TrailingAmount  string `protobuf:"bytes,16,opt,name=trailingAmount,proto3" json:"trailingAmount,omitempty"`   // 跟踪止损的固定价差，type为stop_market/stop_limit时才生效，triggerPrice必须为空，由撮合引擎按最高/最低成交价计算
+	TrailingPercent string `protobuf:"bytes,17,opt,name=trailingPercent,proto3" json:"trailingPercent,omitempty"` // 跟踪止损的比例，0 < 比例 < 1，与trailingAmount只能设置一个
+	Peg             string `protobuf:"bytes,18,opt,name=peg,proto3" json:"peg,omitempty"`                         // 挂钩类型 primary/midpoint，为空时不是挂钩单，GTC/GTD限价单才生效，price必须为空，价格随买一价/卖一价自动调整，只做maker
+	PegOffset       string `protobuf:"bytes,19,opt,name=pegOffset,proto3" json:"pegOffset,omitempty"`             // 挂钩价格偏移，买单为参考价减去偏移，卖单为参考价加上偏移
+	PegLimit        string `protobuf:"bytes,20,opt,name=pegLimit,proto3" json:"pegLimit,omitempty"`               // 挂钩价格上限(买单)或下限(卖单)，为空时不限制
+	Hidden          bool   `protobuf:"varint,21,opt,name=hidden,proto3" json:"hidden,omitempty"`                  // 隐藏单，不出现在深度中，同一价格排在显示的订单之后，GTC/GTD限价单才生效，不能是冰山单
+	MinQty          string `protobuf:"bytes,22,opt,name=minQty,proto3" json:"minQty,omitempty"`                   // 最小成交数量，可以立即成交的数量不足时整单撤销，GTC/GTD/IOC限价单才生效，为空时不限制
+	AllOrNone       bool   `protobuf:"varint,23,opt,name=allOrNone,proto3" json:"allOrNone,omitempty"`            // 全部成交，剩余数量只能一次全部成交，否则挂单等待，GTC/GTD限价单才生效，不能是冰山单、挂钩单或设置minQty
+	ReduceOnly      bool   `protobuf:"varint,24,opt,name=reduceOnly,proto3" json:"reduceOnly,omitempty"`          // 只减仓，数量不超过持仓，持仓减少或反向时由撮合引擎减少或撤销，需要配置持仓接口
+	ClosePosition   bool   `protobuf:"varint,25,opt,name=closePosition,proto3" json:"closePosition,omitempty"`    // 平仓，type为market/stop_market时才生效，amount必须为空，数量为下单或持仓变化时的持仓
 }
 
 func (x *Order) Reset() {
@@ -178,18 +195,281 @@ func (x *Order) GetTimeInForce() string {
 	return ""
 }
 
+func (x *Order) GetTriggerPrice() string {
+	if x != nil {
+		return x.TriggerPrice
+	}
+	return ""
+}
+
+func (x *Order) GetPostOnly() string {
+	if x != nil {
+		return x.PostOnly
+	}
+	return ""
+}
+
+func (x *Order) GetStp() string {
+	if x != nil {
+		return x.Stp
+	}
+	return ""
+}
+
+func (x *Order) GetDisplayAmount() string {
+	if x != nil {
+		return x.DisplayAmount
+	}
+	return ""
+}
+
+func (x *Order) GetExpireTime() int64 {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return 0
+}
+
+func (x *Order) GetQuoteAmount() string {
+	if x != nil {
+		return x.QuoteAmount
+	}
+	return ""
+}
+
+func (x *Order) GetProtectPrice() string {
+	if x != nil {
+		return x.ProtectPrice
+	}
+	return ""
+}
+
+func (x *Order) GetTrailingAmount() string {
+	if x != nil {
+		return x.TrailingAmount
+	}
+	return ""
+}
+
+func (x *Order) GetTrailingPercent() string {
+	if x != nil {
+		return x.TrailingPercent
+	}
+	return ""
+}
+
+func (x *Order) GetPeg() string {
+	if x != nil {
+		return x.Peg
+	}
+	return ""
+}
+
+func (x *Order) GetPegOffset() string {
+	if x != nil {
+		return x.PegOffset
+	}
+	return ""
+}
+
+func (x *Order) GetPegLimit() string {
+	if x != nil {
+		return x.PegLimit
+	}
+	return ""
+}
+
+func (x *Order) GetHidden() bool {
+	if x != nil {
+		return x.Hidden
+	}
+	return false
+}
+
+func (x *Order) GetMinQty() string {
+	if x != nil {
+		return x.MinQty
+	}
+	return ""
+}
+
+func (x *Order) GetAllOrNone() bool {
+	if x != nil {
+		return x.AllOrNone
+	}
+	return false
+}
+
+func (x *Order) GetReduceOnly() bool {
+	if x != nil {
+		return x.ReduceOnly
+	}
+	return false
+}
+
+func (x *Order) GetClosePosition() bool {
+	if x != nil {
+		return x.ClosePosition
+	}
+	return false
+}
+
+type Trade struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id               string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                              // 成交单id
+	Pair             string `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`                          // 交易对
+	MakerId          string `protobuf:"bytes,3,opt,name=makerId,proto3" json:"makerId,omitempty"`                    // maker订单id
+	TakerId          string `protobuf:"bytes,4,opt,name=takerId,proto3" json:"takerId,omitempty"`                    // taker订单id
+	MakerUser        int64  `protobuf:"varint,5,opt,name=makerUser,proto3" json:"makerUser,omitempty"`               // maker用户id
+	TakerUser        int64  `protobuf:"varint,6,opt,name=takerUser,proto3" json:"takerUser,omitempty"`               // taker用户id
+	Price            string `protobuf:"bytes,7,opt,name=price,proto3" json:"price,omitempty"`                        // 成交价
+	Amount           string `protobuf:"bytes,8,opt,name=amount,proto3" json:"amount,omitempty"`                      // 成交数量
+	TakerOrderSide   string `protobuf:"bytes,9,opt,name=takerOrderSide,proto3" json:"takerOrderSide,omitempty"`      // taker订单方向 buy/sell
+	TakerOrderType   string `protobuf:"bytes,10,opt,name=takerOrderType,proto3" json:"takerOrderType,omitempty"`     // taker订单类型 limit/market/cancel/trigger/stp/amend/trail，trail时price为跟踪止损单新的触发价
+	TakerTimeInForce string `protobuf:"bytes,11,opt,name=takerTimeInForce,proto3" json:"takerTimeInForce,omitempty"` // taker订单有效时间 GTC/IOC/FOK
+	Ts               int64  `protobuf:"varint,12,opt,name=ts,proto3" json:"ts,omitempty"`                            // 成交时间
+	Reason           string `protobuf:"bytes,13,opt,name=reason,proto3" json:"reason,omitempty"`                     // 撤销原因
+}
+
+func (x *Trade) Reset() {
+	*x = Trade{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_match_v1_match_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Trade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trade) ProtoMessage() {}
+
+func (x *Trade) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trade.ProtoReflect.Descriptor instead.
+func (*Trade) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Trade) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Trade) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *Trade) GetMakerId() string {
+	if x != nil {
+		return x.MakerId
+	}
+	return ""
+}
+
+func (x *Trade) GetTakerId() string {
+	if x != nil {
+		return x.TakerId
+	}
+	return ""
+}
+
+func (x *Trade) GetMakerUser() int64 {
+	if x != nil {
+		return x.MakerUser
+	}
+	return 0
+}
+
+func (x *Trade) GetTakerUser() int64 {
+	if x != nil {
+		return x.TakerUser
+	}
+	return 0
+}
+
+func (x *Trade) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *Trade) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Trade) GetTakerOrderSide() string {
+	if x != nil {
+		return x.TakerOrderSide
+	}
+	return ""
+}
+
+func (x *Trade) GetTakerOrderType() string {
+	if x != nil {
+		return x.TakerOrderType
+	}
+	return ""
+}
+
+func (x *Trade) GetTakerTimeInForce() string {
+	if x != nil {
+		return x.TakerTimeInForce
+	}
+	return ""
+}
+
+func (x *Trade) GetTs() int64 {
+	if x != nil {
+		return x.Ts
+	}
+	return 0
+}
+
+func (x *Trade) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
 type AddOrderRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Order *Order `protobuf:"bytes,1,opt,name=Order,proto3" json:"Order,omitempty"`
+	Sync  bool   `protobuf:"varint,2,opt,name=Sync,proto3" json:"Sync,omitempty"` // 是否同步等待撮合结果
 }
 
 func (x *AddOrderRequest) Reset() {
 	*x = AddOrderRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_match_v1_match_proto_msgTypes[2]
+		mi := &file_api_match_v1_match_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -202,7 +482,7 @@ func (x *AddOrderRequest) String() string {
 func (*AddOrderRequest) ProtoMessage() {}
 
 func (x *AddOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_match_v1_match_proto_msgTypes[2]
+	mi := &file_api_match_v1_match_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -215,7 +495,7 @@ func (x *AddOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddOrderRequest.ProtoReflect.Descriptor instead.
 func (*AddOrderRequest) Descriptor() ([]byte, []int) {
-	return file_api_match_v1_match_proto_rawDescGZIP(), []int{2}
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *AddOrderRequest) GetOrder() *Order {
@@ -225,18 +505,28 @@ func (x *AddOrderRequest) GetOrder() *Order {
 	return nil
 }
 
+func (x *AddOrderRequest) GetSync() bool {
+	if x != nil {
+		return x.Sync
+	}
+	return false
+}
+
 type AddOrderReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Result *ReplyResult `protobuf:"bytes,1,opt,name=Result,proto3" json:"Result,omitempty"`
+	Result   *ReplyResult `protobuf:"bytes,1,opt,name=Result,proto3" json:"Result,omitempty"`
+	Accepted bool         `protobuf:"varint,2,opt,name=Accepted,proto3" json:"Accepted,omitempty"` // 同步挂单时，订单已被撮合引擎接受
+	Trades   []*Trade     `protobuf:"bytes,3,rep,name=Trades,proto3" json:"Trades,omitempty"`      // 同步挂单时，立即成交的成交单(包括未成交部分的撤销)
+	Reason   string       `protobuf:"bytes,4,opt,name=Reason,proto3" json:"Reason,omitempty"`      // 同步挂单被拒绝时的原因，与撤销记录的Reason相同，IOC/FOK未成交时为空
 }
 
 func (x *AddOrderReply) Reset() {
 	*x = AddOrderReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_match_v1_match_proto_msgTypes[3]
+		mi := &file_api_match_v1_match_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -249,7 +539,7 @@ func (x *AddOrderReply) String() string {
 func (*AddOrderReply) ProtoMessage() {}
 
 func (x *AddOrderReply) ProtoReflect() protoreflect.Message {
-	mi := &file_api_match_v1_match_proto_msgTypes[3]
+	mi := &file_api_match_v1_match_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -262,7 +552,7 @@ func (x *AddOrderReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddOrderReply.ProtoReflect.Descriptor instead.
 func (*AddOrderReply) Descriptor() ([]byte, []int) {
-	return file_api_match_v1_match_proto_rawDescGZIP(), []int{3}
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *AddOrderReply) GetResult() *ReplyResult {
@@ -272,32 +562,53 @@ func (x *AddOrderReply) GetResult() *ReplyResult {
 	return nil
 }
 
-type CancelOrderRequest struct {
+func (x *AddOrderReply) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *AddOrderReply) GetTrades() []*Trade {
+	if x != nil {
+		return x.Trades
+	}
+	return nil
+}
+
+func (x *AddOrderReply) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type AddOcoRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Pair string `protobuf:"bytes,1,opt,name=Pair,proto3" json:"Pair,omitempty"`
-	Id   string `protobuf:"bytes,2,opt,name=Id,proto3" json:"Id,omitempty"`
+	Limit *Order `protobuf:"bytes,1,opt,name=Limit,proto3" json:"Limit,omitempty"` // 止盈限价单 GTC/GTD
+	Stop  *Order `protobuf:"bytes,2,opt,name=Stop,proto3" json:"Stop,omitempty"`   // 止损单 stop_market/stop_limit，与Limit的交易对、用户、方向相同
 }
 
-func (x *CancelOrderRequest) Reset() {
-	*x = CancelOrderRequest{}
+func (x *AddOcoRequest) Reset() {
+	*x = AddOcoRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_match_v1_match_proto_msgTypes[4]
+		mi := &file_api_match_v1_match_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CancelOrderRequest) String() string {
+func (x *AddOcoRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelOrderRequest) ProtoMessage() {}
+func (*AddOcoRequest) ProtoMessage() {}
 
-func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_match_v1_match_proto_msgTypes[4]
+func (x *AddOcoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -308,50 +619,51 @@ func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
-func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
-	return file_api_match_v1_match_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use AddOcoRequest.ProtoReflect.Descriptor instead.
+func (*AddOcoRequest) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *CancelOrderRequest) GetPair() string {
+func (x *AddOcoRequest) GetLimit() *Order {
 	if x != nil {
-		return x.Pair
+		return x.Limit
 	}
-	return ""
+	return nil
 }
 
-func (x *CancelOrderRequest) GetId() string {
+func (x *AddOcoRequest) GetStop() *Order {
 	if x != nil {
-		return x.Id
+		return x.Stop
 	}
-	return ""
+	return nil
 }
 
-type CancelOrderReply struct {
+type AddOcoReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Result *ReplyResult `protobuf:"bytes,1,opt,name=Result,proto3" json:"Result,omitempty"`
+	Trades []*Trade     `protobuf:"bytes,2,rep,name=Trades,proto3" json:"Trades,omitempty"` // 立即成交的成交单，以及被拒绝或撤销的一方
 }
 
-func (x *CancelOrderReply) Reset() {
-	*x = CancelOrderReply{}
+func (x *AddOcoReply) Reset() {
+	*x = AddOcoReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_match_v1_match_proto_msgTypes[5]
+		mi := &file_api_match_v1_match_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CancelOrderReply) String() string {
+func (x *AddOcoReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelOrderReply) ProtoMessage() {}
+func (*AddOcoReply) ProtoMessage() {}
 
-func (x *CancelOrderReply) ProtoReflect() protoreflect.Message {
-	mi := &file_api_match_v1_match_proto_msgTypes[5]
+func (x *AddOcoReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -362,27 +674,1575 @@ func (x *CancelOrderReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelOrderReply.ProtoReflect.Descriptor instead.
-func (*CancelOrderReply) Descriptor() ([]byte, []int) {
-	return file_api_match_v1_match_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use AddOcoReply.ProtoReflect.Descriptor instead.
+func (*AddOcoReply) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *CancelOrderReply) GetResult() *ReplyResult {
+func (x *AddOcoReply) GetResult() *ReplyResult {
 	if x != nil {
 		return x.Result
 	}
 	return nil
 }
 
-var File_api_match_v1_match_proto protoreflect.FileDescriptor
+func (x *AddOcoReply) GetTrades() []*Trade {
+	if x != nil {
+		return x.Trades
+	}
+	return nil
+}
 
-var file_api_match_v1_match_proto_rawDesc = []byte{
-	0x0a, 0x18, 0x61, 0x70, 0x69, 0x2f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2f, 0x76, 0x31, 0x2f, 0x6d,
-	0x61, 0x74, 0x63, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x61, 0x70, 0x69, 0x2e,
+type AddBracketRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entry  *Order `protobuf:"bytes,1,opt,name=Entry,proto3" json:"Entry,omitempty"`   // 父订单，限价单
+	Profit *Order `protobuf:"bytes,2,opt,name=Profit,proto3" json:"Profit,omitempty"` // 止盈限价单 GTC/GTD，与Entry方向相反，amount不生效，父订单成交后按成交数量激活
+	Stop   *Order `protobuf:"bytes,3,opt,name=Stop,proto3" json:"Stop,omitempty"`     // 止损单 stop_market/stop_limit，与Profit的交易对、用户、方向相同，amount不生效
+}
+
+func (x *AddBracketRequest) Reset() {
+	*x = AddBracketRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_match_v1_match_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddBracketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddBracketRequest) ProtoMessage() {}
+
+func (x *AddBracketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddBracketRequest.ProtoReflect.Descriptor instead.
+func (*AddBracketRequest) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AddBracketRequest) GetEntry() *Order {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *AddBracketRequest) GetProfit() *Order {
+	if x != nil {
+		return x.Profit
+	}
+	return nil
+}
+
+func (x *AddBracketRequest) GetStop() *Order {
+	if x != nil {
+		return x.Stop
+	}
+	return nil
+}
+
+type AddBracketReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result *ReplyResult `protobuf:"bytes,1,opt,name=Result,proto3" json:"Result,omitempty"`
+	Trades []*Trade     `protobuf:"bytes,2,rep,name=Trades,proto3" json:"Trades,omitempty"` // 父订单立即成交的成交单，以及激活的子订单产生的记录
+}
+
+func (x *AddBracketReply) Reset() {
+	*x = AddBracketReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_match_v1_match_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddBracketReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddBracketReply) ProtoMessage() {}
+
+func (x *AddBracketReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddBracketReply.ProtoReflect.Descriptor instead.
+func (*AddBracketReply) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AddBracketReply) GetResult() *ReplyResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *AddBracketReply) GetTrades() []*Trade {
+	if x != nil {
+		return x.Trades
+	}
+	return nil
+}
+
+type SyncPositionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pair   string `protobuf:"bytes,1,opt,name=Pair,proto3" json:"Pair,omitempty"`
+	UserId int64  `protobuf:"varint,2,opt,name=UserId,proto3" json:"UserId,omitempty"` // 持仓在撮合之外变化(强平、减仓等)的用户，重新查询持仓并调整只减仓订单
+}
+
+func (x *SyncPositionRequest) Reset() {
+	*x = SyncPositionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_match_v1_match_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncPositionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncPositionRequest) ProtoMessage() {}
+
+func (x *SyncPositionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncPositionRequest.ProtoReflect.Descriptor instead.
+func (*SyncPositionRequest) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SyncPositionRequest) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *SyncPositionRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type SyncPositionReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result *ReplyResult `protobuf:"bytes,1,opt,name=Result,proto3" json:"Result,omitempty"`
+}
+
+func (x *SyncPositionReply) Reset() {
+	*x = SyncPositionReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_match_v1_match_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncPositionReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncPositionReply) ProtoMessage() {}
+
+func (x *SyncPositionReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncPositionReply.ProtoReflect.Descriptor instead.
+func (*SyncPositionReply) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SyncPositionReply) GetResult() *ReplyResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type CancelOrderRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pair string `protobuf:"bytes,1,opt,name=Pair,proto3" json:"Pair,omitempty"`
+	Id   string `protobuf:"bytes,2,opt,name=Id,proto3" json:"Id,omitempty"`
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_match_v1_match_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CancelOrderRequest) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *CancelOrderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CancelOrderReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result *ReplyResult `protobuf:"bytes,1,opt,name=Result,proto3" json:"Result,omitempty"`
+}
+
+func (x *CancelOrderReply) Reset() {
+	*x = CancelOrderReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_match_v1_match_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelOrderReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderReply) ProtoMessage() {}
+
+func (x *CancelOrderReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderReply.ProtoReflect.Descriptor instead.
+func (*CancelOrderReply) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CancelOrderReply) GetResult() *ReplyResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type CancelAllRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pair   string `protobuf:"bytes,1,opt,name=Pair,proto3" json:"Pair,omitempty"`      // 交易对，为空时撤销所有交易对
+	UserId int64  `protobuf:"varint,2,opt,name=UserId,proto3" json:"UserId,omitempty"` // 用户id，为0时撤销所有用户
+	Side   string `protobuf:"bytes,3,opt,name=Side,proto3" json:"Side,omitempty"`      // 订单方向 buy/sell，为空时撤销买卖双方
+}
+
+func (x *CancelAllRequest) Reset() {
+	*x = CancelAllRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_match_v1_match_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelAllRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelAllRequest) ProtoMessage() {}
+
+func (x *CancelAllRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelAllRequest.ProtoReflect.Descriptor instead.
+func (*CancelAllRequest) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CancelAllRequest) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *CancelAllRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CancelAllRequest) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+type CancelAllReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result *ReplyResult `protobuf:"bytes,1,opt,name=Result,proto3" json:"Result,omitempty"`
+	Count  int64        `protobuf:"varint,2,opt,name=Count,proto3" json:"Count,omitempty"` // 撤销的订单数
+}
+
+func (x *CancelAllReply) Reset() {
+	*x = CancelAllReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_match_v1_match_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelAllReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelAllReply) ProtoMessage() {}
+
+func (x *CancelAllReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelAllReply.ProtoReflect.Descriptor instead.
+func (*CancelAllReply) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CancelAllReply) GetResult() *ReplyResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *CancelAllReply) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type AmendOrderRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pair   string `protobuf:"bytes,1,opt,name=Pair,proto3" json:"Pair,omitempty"`
+	Id     string `protobuf:"bytes,2,opt,name=Id,proto3" json:"Id,omitempty"`
+	Price  string `protobuf:"bytes,3,opt,name=Price,proto3" json:"Price,omitempty"`   // 新的价格，为空时不修改
+	Amount string `protobuf:"bytes,4,opt,name=Amount,proto3" json:"Amount,omitempty"` // 新的剩余数量，为空时不修改。只减少数量时保持时间优先级，修改价格或增加数量时重新排队
+}
+
+func (x *AmendOrderRequest) Reset() {
+	*x = AmendOrderRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_match_v1_match_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AmendOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AmendOrderRequest) ProtoMessage() {}
+
+func (x *AmendOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AmendOrderRequest.ProtoReflect.Descriptor instead.
+func (*AmendOrderRequest) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AmendOrderRequest) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *AmendOrderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AmendOrderRequest) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *AmendOrderRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+type AmendOrderReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result *ReplyResult `protobuf:"bytes,1,opt,name=Result,proto3" json:"Result,omitempty"`
+	Trades []*Trade     `protobuf:"bytes,2,rep,name=Trades,proto3" json:"Trades,omitempty"` // 改单事件，以及改价后立即成交的成交单
+}
+
+func (x *AmendOrderReply) Reset() {
+	*x = AmendOrderReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_match_v1_match_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AmendOrderReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AmendOrderReply) ProtoMessage() {}
+
+func (x *AmendOrderReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AmendOrderReply.ProtoReflect.Descriptor instead.
+func (*AmendOrderReply) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AmendOrderReply) GetResult() *ReplyResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *AmendOrderReply) GetTrades() []*Trade {
+	if x != nil {
+		return x.Trades
+	}
+	return nil
+}
+
+type DepthLevel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Price  string `protobuf:"bytes,1,opt,name=price,proto3" json:"price,omitempty"`   // 价格
+	Amount string `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"` // 该价格下的总数量，冰山单只计算显示数量，不包括隐藏单
+	Count  int64  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`  // 该价格下的订单数
+}
+
+func (x *DepthLevel) Reset() {
+	*x = DepthLevel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_match_v1_match_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DepthLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DepthLevel) ProtoMessage() {}
+
+func (x *DepthLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DepthLevel.ProtoReflect.Descriptor instead.
+func (*DepthLevel) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DepthLevel) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *DepthLevel) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *DepthLevel) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type Depth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pair string        `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"` // 交易对
+	Bids []*DepthLevel `protobuf:"bytes,2,rep,name=bids,proto3" json:"bids,omitempty"` // 买盘档位，价格从高到低
+	Asks []*DepthLevel `protobuf:"bytes,3,rep,name=asks,proto3" json:"asks,omitempty"` // 卖盘档位，价格从低到高
+	Ts   int64         `protobuf:"varint,4,opt,name=ts,proto3" json:"ts,omitempty"`    // 深度生成时间
+}
+
+func (x *Depth) Reset() {
+	*x = Depth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_match_v1_match_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Depth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Depth) ProtoMessage() {}
+
+func (x *Depth) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Depth.ProtoReflect.Descriptor instead.
+func (*Depth) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Depth) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *Depth) GetBids() []*DepthLevel {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
+}
+
+func (x *Depth) GetAsks() []*DepthLevel {
+	if x != nil {
+		return x.Asks
+	}
+	return nil
+}
+
+func (x *Depth) GetTs() int64 {
+	if x != nil {
+		return x.Ts
+	}
+	return 0
+}
+
+type GetDepthRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pair   string `protobuf:"bytes,1,opt,name=Pair,proto3" json:"Pair,omitempty"`
+	Levels int32  `protobuf:"varint,2,opt,name=Levels,proto3" json:"Levels,omitempty"` // 档位数，<=0时返回全部档位
+}
+
+func (x *GetDepthRequest) Reset() {
+	*x = GetDepthRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_match_v1_match_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetDepthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDepthRequest) ProtoMessage() {}
+
+func (x *GetDepthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDepthRequest.ProtoReflect.Descriptor instead.
+func (*GetDepthRequest) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetDepthRequest) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *GetDepthRequest) GetLevels() int32 {
+	if x != nil {
+		return x.Levels
+	}
+	return 0
+}
+
+type GetDepthReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result *ReplyResult `protobuf:"bytes,1,opt,name=Result,proto3" json:"Result,omitempty"`
+	Depth  *Depth       `protobuf:"bytes,2,opt,name=Depth,proto3" json:"Depth,omitempty"`
+}
+
+func (x *GetDepthReply) Reset() {
+	*x = GetDepthReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_match_v1_match_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetDepthReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDepthReply) ProtoMessage() {}
+
+func (x *GetDepthReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDepthReply.ProtoReflect.Descriptor instead.
+func (*GetDepthReply) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetDepthReply) GetResult() *ReplyResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *GetDepthReply) GetDepth() *Depth {
+	if x != nil {
+		return x.Depth
+	}
+	return nil
+}
+
+type Pair struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol          string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`                     // 交易对名称
+	LotSize         string `protobuf:"bytes,2,opt,name=lotSize,proto3" json:"lotSize,omitempty"`                   // 数量的最小变动单位，以下字段为空或0时不限制
+	PriceBand       string `protobuf:"bytes,3,opt,name=priceBand,proto3" json:"priceBand,omitempty"`               // 市价单价格保护范围，相对最优对手价的比例
+	PriceTick       string `protobuf:"bytes,4,opt,name=priceTick,proto3" json:"priceTick,omitempty"`               // 价格的最小变动单位
+	PriceScale      int32  `protobuf:"varint,5,opt,name=priceScale,proto3" json:"priceScale,omitempty"`            // 价格的最大小数位数
+	AmountScale     int32  `protobuf:"varint,6,opt,name=amountScale,proto3" json:"amountScale,omitempty"`          // 数量的最大小数位数
+	MinAmount       string `protobuf:"bytes,7,opt,name=minAmount,proto3" json:"minAmount,omitempty"`               // 单笔最小数量
+	MaxAmount       string `protobuf:"bytes,8,opt,name=maxAmount,proto3" json:"maxAmount,omitempty"`               // 单笔最大数量
+	MinNotional     string `protobuf:"bytes,9,opt,name=minNotional,proto3" json:"minNotional,omitempty"`           // 单笔最小金额
+	MaxNotional     string `protobuf:"bytes,10,opt,name=maxNotional,proto3" json:"maxNotional,omitempty"`          // 单笔最大金额
+	BreakerPercent  string `protobuf:"bytes,11,opt,name=breakerPercent,proto3" json:"breakerPercent,omitempty"`    // 熔断阈值，成交价相对窗口内最高/最低成交价的波动比例，为空或0时不熔断
+	BreakerWindow   int64  `protobuf:"varint,12,opt,name=breakerWindow,proto3" json:"breakerWindow,omitempty"`     // 熔断窗口(毫秒)
+	BreakerDuration int64  `protobuf:"varint,13,opt,name=breakerDuration,proto3" json:"breakerDuration,omitempty"` // 熔断持续时间(毫秒)，到期后自动恢复，为0时需调用ResumePair/Uncross恢复
+	BreakerAuction  bool   `protobuf:"varint,14,opt,name=breakerAuction,proto3" json:"breakerAuction,omitempty"`   // 熔断后进入波动性集合竞价，否则暂停交易
+	Allocation      string `protobuf:"bytes,15,opt,name=allocation,proto3" json:"allocation,omitempty"`            // 同一价格档位的分配算法 fifo/pro_rata/hybrid，为空时为fifo
+	AllocationTop   string `protobuf:"bytes,16,opt,name=allocationTop,proto3" json:"allocationTop,omitempty"`      // hybrid算法中时间最早的订单优先分配taker数量的比例，0 < 比例 <= 1
+}
+
+func (x *Pair) Reset() {
+	*x = Pair{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_match_v1_match_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Pair) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pair) ProtoMessage() {}
+
+func (x *Pair) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pair.ProtoReflect.Descriptor instead.
+func (*Pair) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Pair) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Pair) GetLotSize() string {
+	if x != nil {
+		return x.LotSize
+	}
+	return ""
+}
+
+func (x *Pair) GetPriceBand() string {
+	if x != nil {
+		return x.PriceBand
+	}
+	return ""
+}
+
+func (x *Pair) GetPriceTick() string {
+	if x != nil {
+		return x.PriceTick
+	}
+	return ""
+}
+
+func (x *Pair) GetPriceScale() int32 {
+	if x != nil {
+		return x.PriceScale
+	}
+	return 0
+}
+
+func (x *Pair) GetAmountScale() int32 {
+	if x != nil {
+		return x.AmountScale
+	}
+	return 0
+}
+
+func (x *Pair) GetMinAmount() string {
+	if x != nil {
+		return x.MinAmount
+	}
+	return ""
+}
+
+func (x *Pair) GetMaxAmount() string {
+	if x != nil {
+		return x.MaxAmount
+	}
+	return ""
+}
+
+func (x *Pair) GetMinNotional() string {
+	if x != nil {
+		return x.MinNotional
+	}
+	return ""
+}
+
+func (x *Pair) GetMaxNotional() string {
+	if x != nil {
+		return x.MaxNotional
+	}
+	return ""
+}
+
+func (x *Pair) GetBreakerPercent() string {
+	if x != nil {
+		return x.BreakerPercent
+	}
+	return ""
+}
+
+func (x *Pair) GetBreakerWindow() int64 {
+	if x != nil {
+		return x.BreakerWindow
+	}
+	return 0
+}
+
+func (x *Pair) GetBreakerDuration() int64 {
+	if x != nil {
+		return x.BreakerDuration
+	}
+	return 0
+}
+
+func (x *Pair) GetBreakerAuction() bool {
+	if x != nil {
+		return x.BreakerAuction
+	}
+	return false
+}
+
+func (x *Pair) GetAllocation() string {
+	if x != nil {
+		return x.Allocation
+	}
+	return ""
+}
+
+func (x *Pair) GetAllocationTop() string {
+	if x != nil {
+		return x.AllocationTop
+	}
+	return ""
+}
+
+type AddPairRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pair *Pair `protobuf:"bytes,1,opt,name=Pair,proto3" json:"Pair,omitempty"`
+}
+
+func (x *AddPairRequest) Reset() {
+	*x = AddPairRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_match_v1_match_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddPairRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddPairRequest) ProtoMessage() {}
+
+func (x *AddPairRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddPairRequest.ProtoReflect.Descriptor instead.
+func (*AddPairRequest) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AddPairRequest) GetPair() *Pair {
+	if x != nil {
+		return x.Pair
+	}
+	return nil
+}
+
+type AddPairReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result *ReplyResult `protobuf:"bytes,1,opt,name=Result,proto3" json:"Result,omitempty"`
+}
+
+func (x *AddPairReply) Reset() {
+	*x = AddPairReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_match_v1_match_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddPairReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddPairReply) ProtoMessage() {}
+
+func (x *AddPairReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddPairReply.ProtoReflect.Descriptor instead.
+func (*AddPairReply) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AddPairReply) GetResult() *ReplyResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type HaltPairRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pair string `protobuf:"bytes,1,opt,name=Pair,proto3" json:"Pair,omitempty"`
+}
+
+func (x *HaltPairRequest) Reset() {
+	*x = HaltPairRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_match_v1_match_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HaltPairRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HaltPairRequest) ProtoMessage() {}
+
+func (x *HaltPairRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HaltPairRequest.ProtoReflect.Descriptor instead.
+func (*HaltPairRequest) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *HaltPairRequest) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+type HaltPairReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result *ReplyResult `protobuf:"bytes,1,opt,name=Result,proto3" json:"Result,omitempty"`
+}
+
+func (x *HaltPairReply) Reset() {
+	*x = HaltPairReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_match_v1_match_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HaltPairReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HaltPairReply) ProtoMessage() {}
+
+func (x *HaltPairReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HaltPairReply.ProtoReflect.Descriptor instead.
+func (*HaltPairReply) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *HaltPairReply) GetResult() *ReplyResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type ResumePairRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pair string `protobuf:"bytes,1,opt,name=Pair,proto3" json:"Pair,omitempty"`
+}
+
+func (x *ResumePairRequest) Reset() {
+	*x = ResumePairRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_match_v1_match_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResumePairRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumePairRequest) ProtoMessage() {}
+
+func (x *ResumePairRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumePairRequest.ProtoReflect.Descriptor instead.
+func (*ResumePairRequest) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ResumePairRequest) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+type ResumePairReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result *ReplyResult `protobuf:"bytes,1,opt,name=Result,proto3" json:"Result,omitempty"`
+}
+
+func (x *ResumePairReply) Reset() {
+	*x = ResumePairReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_match_v1_match_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResumePairReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumePairReply) ProtoMessage() {}
+
+func (x *ResumePairReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumePairReply.ProtoReflect.Descriptor instead.
+func (*ResumePairReply) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ResumePairReply) GetResult() *ReplyResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type DelistPairRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pair string `protobuf:"bytes,1,opt,name=Pair,proto3" json:"Pair,omitempty"`
+}
+
+func (x *DelistPairRequest) Reset() {
+	*x = DelistPairRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_match_v1_match_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DelistPairRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DelistPairRequest) ProtoMessage() {}
+
+func (x *DelistPairRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DelistPairRequest.ProtoReflect.Descriptor instead.
+func (*DelistPairRequest) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DelistPairRequest) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+type DelistPairReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result *ReplyResult `protobuf:"bytes,1,opt,name=Result,proto3" json:"Result,omitempty"`
+}
+
+func (x *DelistPairReply) Reset() {
+	*x = DelistPairReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_match_v1_match_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DelistPairReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DelistPairReply) ProtoMessage() {}
+
+func (x *DelistPairReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DelistPairReply.ProtoReflect.Descriptor instead.
+func (*DelistPairReply) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DelistPairReply) GetResult() *ReplyResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type StartAuctionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pair string `protobuf:"bytes,1,opt,name=Pair,proto3" json:"Pair,omitempty"`
+}
+
+func (x *StartAuctionRequest) Reset() {
+	*x = StartAuctionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_match_v1_match_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StartAuctionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartAuctionRequest) ProtoMessage() {}
+
+func (x *StartAuctionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartAuctionRequest.ProtoReflect.Descriptor instead.
+func (*StartAuctionRequest) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *StartAuctionRequest) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+type StartAuctionReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result *ReplyResult `protobuf:"bytes,1,opt,name=Result,proto3" json:"Result,omitempty"`
+}
+
+func (x *StartAuctionReply) Reset() {
+	*x = StartAuctionReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_match_v1_match_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StartAuctionReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartAuctionReply) ProtoMessage() {}
+
+func (x *StartAuctionReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartAuctionReply.ProtoReflect.Descriptor instead.
+func (*StartAuctionReply) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *StartAuctionReply) GetResult() *ReplyResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type UncrossRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pair string `protobuf:"bytes,1,opt,name=Pair,proto3" json:"Pair,omitempty"`
+}
+
+func (x *UncrossRequest) Reset() {
+	*x = UncrossRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_match_v1_match_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UncrossRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UncrossRequest) ProtoMessage() {}
+
+func (x *UncrossRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UncrossRequest.ProtoReflect.Descriptor instead.
+func (*UncrossRequest) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UncrossRequest) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+type UncrossReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result *ReplyResult `protobuf:"bytes,1,opt,name=Result,proto3" json:"Result,omitempty"`
+}
+
+func (x *UncrossReply) Reset() {
+	*x = UncrossReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_match_v1_match_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UncrossReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UncrossReply) ProtoMessage() {}
+
+func (x *UncrossReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_match_v1_match_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UncrossReply.ProtoReflect.Descriptor instead.
+func (*UncrossReply) Descriptor() ([]byte, []int) {
+	return file_api_match_v1_match_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *UncrossReply) GetResult() *ReplyResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+var File_api_match_v1_match_proto protoreflect.FileDescriptor
+
+var file_api_match_v1_match_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x61, 0x70, 0x69, 0x2f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2f, 0x76, 0x31, 0x2f, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x61, 0x70, 0x69, 0x2e,
 	0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x22, 0x33, 0x0a, 0x0b, 0x52, 0x65, 0x70, 0x6c,
 	0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x43, 0x6f, 0x64, 0x65, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x4d,
-	0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x4d, 0x73, 0x67, 0x22, 0xbb, 0x01,
+	0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x4d, 0x73, 0x67, 0x22, 0xcb, 0x05,
 	0x0a, 0x05, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
 	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12,
@@ -394,37 +2254,323 @@ var file_api_match_v1_match_proto_rawDesc = []byte{
 	0x04, 0x73, 0x69, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x07, 0x20,
 	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x69, 0x6d,
 	0x65, 0x49, 0x6e, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
-	0x74, 0x69, 0x6d, 0x65, 0x49, 0x6e, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x22, 0x3c, 0x0a, 0x0f, 0x41,
+	0x74, 0x69, 0x6d, 0x65, 0x49, 0x6e, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x74,
+	0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x74, 0x4f, 0x6e, 0x6c, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x74, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x73,
+	0x74, 0x70, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x74, 0x70, 0x12, 0x24, 0x0a,
+	0x0d, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x41, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x54, 0x69, 0x6d,
+	0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x41, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x41,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x74, 0x65, 0x63, 0x74,
+	0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x6f,
+	0x74, 0x65, 0x63, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x74, 0x72, 0x61,
+	0x69, 0x6c, 0x69, 0x6e, 0x67, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x74, 0x72, 0x61, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x41, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x28, 0x0a, 0x0f, 0x74, 0x72, 0x61, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x50, 0x65, 0x72,
+	0x63, 0x65, 0x6e, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x74, 0x72, 0x61, 0x69,
+	0x6c, 0x69, 0x6e, 0x67, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x70,
+	0x65, 0x67, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x65, 0x67, 0x12, 0x1c, 0x0a,
+	0x09, 0x70, 0x65, 0x67, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x70, 0x65, 0x67, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70,
+	0x65, 0x67, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70,
+	0x65, 0x67, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x69, 0x64, 0x64, 0x65,
+	0x6e, 0x18, 0x15, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x12,
+	0x16, 0x0a, 0x06, 0x6d, 0x69, 0x6e, 0x51, 0x74, 0x79, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x6d, 0x69, 0x6e, 0x51, 0x74, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x6c, 0x6c, 0x4f, 0x72,
+	0x4e, 0x6f, 0x6e, 0x65, 0x18, 0x17, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x61, 0x6c, 0x6c, 0x4f,
+	0x72, 0x4e, 0x6f, 0x6e, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x64, 0x75, 0x63, 0x65, 0x4f,
+	0x6e, 0x6c, 0x79, 0x18, 0x18, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x72, 0x65, 0x64, 0x75, 0x63,
+	0x65, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x50, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x19, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x63, 0x6c,
+	0x6f, 0x73, 0x65, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xed, 0x02, 0x0a, 0x05,
+	0x54, 0x72, 0x61, 0x64, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x61, 0x6b,
+	0x65, 0x72, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x6b, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x61, 0x6b, 0x65, 0x72, 0x49, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x61, 0x6b, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1c, 0x0a,
+	0x09, 0x6d, 0x61, 0x6b, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x09, 0x6d, 0x61, 0x6b, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x74,
+	0x61, 0x6b, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x74, 0x61, 0x6b, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x74, 0x61, 0x6b, 0x65, 0x72,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x69, 0x64, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0e, 0x74, 0x61, 0x6b, 0x65, 0x72, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x69, 0x64, 0x65, 0x12,
+	0x26, 0x0a, 0x0e, 0x74, 0x61, 0x6b, 0x65, 0x72, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70,
+	0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x74, 0x61, 0x6b, 0x65, 0x72, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x74, 0x61, 0x6b, 0x65, 0x72,
+	0x54, 0x69, 0x6d, 0x65, 0x49, 0x6e, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x10, 0x74, 0x61, 0x6b, 0x65, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x49, 0x6e, 0x46, 0x6f,
+	0x72, 0x63, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x02, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x50, 0x0a, 0x0f, 0x41,
 	0x64, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29,
 	0x0a, 0x05, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
 	0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64,
-	0x65, 0x72, 0x52, 0x05, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x22, 0x42, 0x0a, 0x0d, 0x41, 0x64, 0x64,
+	0x65, 0x72, 0x52, 0x05, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x79, 0x6e,
+	0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x53, 0x79, 0x6e, 0x63, 0x22, 0xa3, 0x01,
+	0x0a, 0x0d, 0x41, 0x64, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x31, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x12, 0x2b,
+	0x0a, 0x06, 0x54, 0x72, 0x61, 0x64, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72,
+	0x61, 0x64, 0x65, 0x52, 0x06, 0x54, 0x72, 0x61, 0x64, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x52,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x52, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x22, 0x63, 0x0a, 0x0d, 0x41, 0x64, 0x64, 0x4f, 0x63, 0x6f, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x05, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e,
+	0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x05, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12,
+	0x27, 0x0a, 0x04, 0x53, 0x74, 0x6f, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x52, 0x04, 0x53, 0x74, 0x6f, 0x70, 0x22, 0x6d, 0x0a, 0x0b, 0x41, 0x64, 0x64, 0x4f,
+	0x63, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x31, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x52, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x2b, 0x0a, 0x06, 0x54, 0x72,
+	0x61, 0x64, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x64, 0x65, 0x52,
+	0x06, 0x54, 0x72, 0x61, 0x64, 0x65, 0x73, 0x22, 0x94, 0x01, 0x0a, 0x11, 0x41, 0x64, 0x64, 0x42,
+	0x72, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a,
+	0x05, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x52, 0x05, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x2b, 0x0a, 0x06, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x06, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x74, 0x12, 0x27, 0x0a, 0x04, 0x53, 0x74, 0x6f, 0x70, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e,
+	0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x04, 0x53, 0x74, 0x6f, 0x70, 0x22, 0x71,
+	0x0a, 0x0f, 0x41, 0x64, 0x64, 0x42, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x31, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x12, 0x2b, 0x0a, 0x06, 0x54, 0x72, 0x61, 0x64, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x64, 0x65, 0x52, 0x06, 0x54, 0x72, 0x61, 0x64, 0x65,
+	0x73, 0x22, 0x41, 0x0a, 0x13, 0x53, 0x79, 0x6e, 0x63, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x61, 0x69, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x50, 0x61, 0x69, 0x72, 0x12, 0x16, 0x0a, 0x06,
+	0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x55, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x22, 0x46, 0x0a, 0x11, 0x53, 0x79, 0x6e, 0x63, 0x50, 0x6f, 0x73, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x31, 0x0a, 0x06, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x38, 0x0a, 0x12,
+	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x61, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x50, 0x61, 0x69, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x49, 0x64, 0x22, 0x45, 0x0a, 0x10, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c,
 	0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x31, 0x0a, 0x06, 0x52, 0x65,
 	0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x61, 0x70, 0x69,
 	0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x52,
-	0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x38, 0x0a,
-	0x12, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x61, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x50, 0x61, 0x69, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x64, 0x22, 0x45, 0x0a, 0x10, 0x43, 0x61, 0x6e, 0x63, 0x65,
-	0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x31, 0x0a, 0x06, 0x52,
-	0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x32, 0xab,
-	0x01, 0x0a, 0x0c, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
-	0x48, 0x0a, 0x08, 0x41, 0x64, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1d, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x4f, 0x72,
-	0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x70, 0x69,
-	0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x4f, 0x72, 0x64,
-	0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x0b, 0x43, 0x61, 0x6e,
-	0x63, 0x65, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x20, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d,
-	0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x72,
-	0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x61, 0x70, 0x69,
-	0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c,
-	0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x21, 0x0a, 0x0c,
-	0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x50, 0x01, 0x5a, 0x0f,
-	0x61, 0x70, 0x69, 0x2f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2f, 0x76, 0x31, 0x3b, 0x76, 0x31, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x52, 0x0a,
+	0x10, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x61, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x50, 0x61, 0x69, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x53, 0x69, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x53, 0x69, 0x64,
+	0x65, 0x22, 0x59, 0x0a, 0x0e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x41, 0x6c, 0x6c, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x31, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x65, 0x0a, 0x11,
+	0x41, 0x6d, 0x65, 0x6e, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x61, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x50, 0x61, 0x69, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x41,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x41, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x22, 0x71, 0x0a, 0x0f, 0x41, 0x6d, 0x65, 0x6e, 0x64, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x31, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x52, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x2b, 0x0a, 0x06, 0x54, 0x72, 0x61,
+	0x64, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x64, 0x65, 0x52, 0x06,
+	0x54, 0x72, 0x61, 0x64, 0x65, 0x73, 0x22, 0x50, 0x0a, 0x0a, 0x44, 0x65, 0x70, 0x74, 0x68, 0x4c,
+	0x65, 0x76, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x87, 0x01, 0x0a, 0x05, 0x44, 0x65, 0x70,
+	0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x70, 0x61, 0x69, 0x72, 0x12, 0x2c, 0x0a, 0x04, 0x62, 0x69, 0x64, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x70, 0x74, 0x68, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x04,
+	0x62, 0x69, 0x64, 0x73, 0x12, 0x2c, 0x0a, 0x04, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76,
+	0x31, 0x2e, 0x44, 0x65, 0x70, 0x74, 0x68, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x04, 0x61, 0x73,
+	0x6b, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02,
+	0x74, 0x73, 0x22, 0x3d, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x44, 0x65, 0x70, 0x74, 0x68, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x61, 0x69, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x50, 0x61, 0x69, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x65, 0x76,
+	0x65, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x4c, 0x65, 0x76, 0x65, 0x6c,
+	0x73, 0x22, 0x6d, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x44, 0x65, 0x70, 0x74, 0x68, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x31, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x29, 0x0a, 0x05, 0x44, 0x65, 0x70, 0x74, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x70, 0x74, 0x68, 0x52, 0x05, 0x44, 0x65, 0x70, 0x74, 0x68,
+	0x22, 0x9c, 0x04, 0x0a, 0x04, 0x50, 0x61, 0x69, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d,
+	0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f,
+	0x6c, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x6f, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6c, 0x6f, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x42, 0x61, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x42, 0x61, 0x6e, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x54, 0x69, 0x63, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x54, 0x69, 0x63, 0x6b, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x53, 0x63, 0x61, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x69, 0x6e,
+	0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x69,
+	0x6e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x41, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x41,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x69, 0x6e, 0x4e, 0x6f, 0x74, 0x69,
+	0x6f, 0x6e, 0x61, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x69, 0x6e, 0x4e,
+	0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x4e, 0x6f,
+	0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x61,
+	0x78, 0x4e, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x12, 0x26, 0x0a, 0x0e, 0x62, 0x72, 0x65,
+	0x61, 0x6b, 0x65, 0x72, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e,
+	0x74, 0x12, 0x24, 0x0a, 0x0d, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x57, 0x69, 0x6e, 0x64,
+	0x6f, 0x77, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x65,
+	0x72, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x28, 0x0a, 0x0f, 0x62, 0x72, 0x65, 0x61, 0x6b,
+	0x65, 0x72, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0f, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x41, 0x75, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x62, 0x72, 0x65, 0x61, 0x6b,
+	0x65, 0x72, 0x41, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x6c, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61,
+	0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x0d, 0x61, 0x6c, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x70, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x70, 0x22,
+	0x38, 0x0a, 0x0e, 0x41, 0x64, 0x64, 0x50, 0x61, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x26, 0x0a, 0x04, 0x50, 0x61, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x61, 0x69, 0x72, 0x52, 0x04, 0x50, 0x61, 0x69, 0x72, 0x22, 0x41, 0x0a, 0x0c, 0x41, 0x64, 0x64,
+	0x50, 0x61, 0x69, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x31, 0x0a, 0x06, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x25, 0x0a, 0x0f,
+	0x48, 0x61, 0x6c, 0x74, 0x50, 0x61, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x50, 0x61, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x50,
+	0x61, 0x69, 0x72, 0x22, 0x42, 0x0a, 0x0d, 0x48, 0x61, 0x6c, 0x74, 0x50, 0x61, 0x69, 0x72, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x31, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52,
+	0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x27, 0x0a, 0x11, 0x52, 0x65, 0x73, 0x75, 0x6d,
+	0x65, 0x50, 0x61, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x50, 0x61, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x50, 0x61, 0x69, 0x72,
+	0x22, 0x44, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x50, 0x61, 0x69, 0x72, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x31, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x27, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x69, 0x73, 0x74,
+	0x50, 0x61, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x50,
+	0x61, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x50, 0x61, 0x69, 0x72, 0x22,
+	0x44, 0x0a, 0x0f, 0x44, 0x65, 0x6c, 0x69, 0x73, 0x74, 0x50, 0x61, 0x69, 0x72, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x31, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x29, 0x0a, 0x13, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x75,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x50, 0x61, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x50, 0x61, 0x69, 0x72,
+	0x22, 0x46, 0x0a, 0x11, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x31, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x52, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x24, 0x0a, 0x0e, 0x55, 0x6e, 0x63, 0x72,
+	0x6f, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x61,
+	0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x50, 0x61, 0x69, 0x72, 0x22, 0x41,
+	0x0a, 0x0c, 0x55, 0x6e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x31,
+	0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x32, 0xfc, 0x04, 0x0a, 0x0c, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0x48, 0x0a, 0x08, 0x41, 0x64, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1d,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64,
+	0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x0b,
+	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x20, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
+	0x48, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x44, 0x65, 0x70, 0x74, 0x68, 0x12, 0x1d, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x65,
+	0x70, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x65, 0x70,
+	0x74, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x0a, 0x41, 0x6d, 0x65,
+	0x6e, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6d, 0x65, 0x6e, 0x64, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6d, 0x65, 0x6e, 0x64, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x09, 0x43, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x41, 0x6c, 0x6c, 0x12, 0x1e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x41, 0x6c, 0x6c, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x41, 0x6c, 0x6c, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x06, 0x41, 0x64, 0x64, 0x4f, 0x63, 0x6f,
+	0x12, 0x1b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x64, 0x64, 0x4f, 0x63, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64,
+	0x4f, 0x63, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x0c, 0x53, 0x79,
+	0x6e, 0x63, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x50, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x6e,
+	0x63, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x12, 0x4e, 0x0a, 0x0a, 0x41, 0x64, 0x64, 0x42, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x1f,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64,
+	0x64, 0x42, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x64, 0x64, 0x42, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x32, 0xdc, 0x03, 0x0a, 0x0c, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x45, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x50, 0x61, 0x69, 0x72, 0x12, 0x1c, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x50,
+	0x61, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x50, 0x61, 0x69,
+	0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x08, 0x48, 0x61, 0x6c, 0x74,
+	0x50, 0x61, 0x69, 0x72, 0x12, 0x1d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x2e, 0x76, 0x31, 0x2e, 0x48, 0x61, 0x6c, 0x74, 0x50, 0x61, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e,
+	0x76, 0x31, 0x2e, 0x48, 0x61, 0x6c, 0x74, 0x50, 0x61, 0x69, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x4e, 0x0a, 0x0a, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x50, 0x61, 0x69, 0x72,
+	0x12, 0x1f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x50, 0x61, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x50, 0x61, 0x69, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x4e, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x69, 0x73, 0x74, 0x50, 0x61, 0x69, 0x72,
+	0x12, 0x1f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x65, 0x6c, 0x69, 0x73, 0x74, 0x50, 0x61, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31,
+	0x2e, 0x44, 0x65, 0x6c, 0x69, 0x73, 0x74, 0x50, 0x61, 0x69, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x54, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x75, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x21, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x75, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x07, 0x55, 0x6e, 0x63, 0x72,
+	0x6f, 0x73, 0x73, 0x12, 0x1c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e,
+	0x76, 0x31, 0x2e, 0x55, 0x6e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31,
+	0x2e, 0x55, 0x6e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42,
+	0x21, 0x0a, 0x0c, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x50,
+	0x01, 0x5a, 0x0f, 0x61, 0x70, 0x69, 0x2f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2f, 0x76, 0x31, 0x3b,
+	0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -439,28 +2585,105 @@ func file_api_match_v1_match_proto_rawDescGZIP() []byte {
 	return file_api_match_v1_match_proto_rawDescData
 }
 
-var file_api_match_v1_match_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_api_match_v1_match_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
 var file_api_match_v1_match_proto_goTypes = []interface{}{
-	(*ReplyResult)(nil),        // 0: api.match.v1.ReplyResult
-	(*Order)(nil),              // 1: api.match.v1.Order
-	(*AddOrderRequest)(nil),    // 2: api.match.v1.AddOrderRequest
-	(*AddOrderReply)(nil),      // 3: api.match.v1.AddOrderReply
-	(*CancelOrderRequest)(nil), // 4: api.match.v1.CancelOrderRequest
-	(*CancelOrderReply)(nil),   // 5: api.match.v1.CancelOrderReply
+	(*ReplyResult)(nil),         // 0: api.match.v1.ReplyResult
+	(*Order)(nil),               // 1: api.match.v1.Order
+	(*Trade)(nil),               // 2: api.match.v1.Trade
+	(*AddOrderRequest)(nil),     // 3: api.match.v1.AddOrderRequest
+	(*AddOrderReply)(nil),       // 4: api.match.v1.AddOrderReply
+	(*AddOcoRequest)(nil),       // 5: api.match.v1.AddOcoRequest
+	(*AddOcoReply)(nil),         // 6: api.match.v1.AddOcoReply
+	(*AddBracketRequest)(nil),   // 7: api.match.v1.AddBracketRequest
+	(*AddBracketReply)(nil),     // 8: api.match.v1.AddBracketReply
+	(*SyncPositionRequest)(nil), // 9: api.match.v1.SyncPositionRequest
+	(*SyncPositionReply)(nil),   // 10: api.match.v1.SyncPositionReply
+	(*CancelOrderRequest)(nil),  // 11: api.match.v1.CancelOrderRequest
+	(*CancelOrderReply)(nil),    // 12: api.match.v1.CancelOrderReply
+	(*CancelAllRequest)(nil),    // 13: api.match.v1.CancelAllRequest
+	(*CancelAllReply)(nil),      // 14: api.match.v1.CancelAllReply
+	(*AmendOrderRequest)(nil),   // 15: api.match.v1.AmendOrderRequest
+	(*AmendOrderReply)(nil),     // 16: api.match.v1.AmendOrderReply
+	(*DepthLevel)(nil),          // 17: api.match.v1.DepthLevel
+	(*Depth)(nil),               // 18: api.match.v1.Depth
+	(*GetDepthRequest)(nil),     // 19: api.match.v1.GetDepthRequest
+	(*GetDepthReply)(nil),       // 20: api.match.v1.GetDepthReply
+	(*Pair)(nil),                // 21: api.match.v1.Pair
+	(*AddPairRequest)(nil),      // 22: api.match.v1.AddPairRequest
+	(*AddPairReply)(nil),        // 23: api.match.v1.AddPairReply
+	(*HaltPairRequest)(nil),     // 24: api.match.v1.HaltPairRequest
+	(*HaltPairReply)(nil),       // 25: api.match.v1.HaltPairReply
+	(*ResumePairRequest)(nil),   // 26: api.match.v1.ResumePairRequest
+	(*ResumePairReply)(nil),     // 27: api.match.v1.ResumePairReply
+	(*DelistPairRequest)(nil),   // 28: api.match.v1.DelistPairRequest
+	(*DelistPairReply)(nil),     // 29: api.match.v1.DelistPairReply
+	(*StartAuctionRequest)(nil), // 30: api.match.v1.StartAuctionRequest
+	(*StartAuctionReply)(nil),   // 31: api.match.v1.StartAuctionReply
+	(*UncrossRequest)(nil),      // 32: api.match.v1.UncrossRequest
+	(*UncrossReply)(nil),        // 33: api.match.v1.UncrossReply
 }
 var file_api_match_v1_match_proto_depIdxs = []int32{
-	1, // 0: api.match.v1.AddOrderRequest.Order:type_name -> api.match.v1.Order
-	0, // 1: api.match.v1.AddOrderReply.Result:type_name -> api.match.v1.ReplyResult
-	0, // 2: api.match.v1.CancelOrderReply.Result:type_name -> api.match.v1.ReplyResult
-	2, // 3: api.match.v1.MatchService.AddOrder:input_type -> api.match.v1.AddOrderRequest
-	4, // 4: api.match.v1.MatchService.CancelOrder:input_type -> api.match.v1.CancelOrderRequest
-	3, // 5: api.match.v1.MatchService.AddOrder:output_type -> api.match.v1.AddOrderReply
-	5, // 6: api.match.v1.MatchService.CancelOrder:output_type -> api.match.v1.CancelOrderReply
-	5, // [5:7] is the sub-list for method output_type
-	3, // [3:5] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	1,  // 0: api.match.v1.AddOrderRequest.Order:type_name -> api.match.v1.Order
+	0,  // 1: api.match.v1.AddOrderReply.Result:type_name -> api.match.v1.ReplyResult
+	2,  // 2: api.match.v1.AddOrderReply.Trades:type_name -> api.match.v1.Trade
+	1,  // 3: api.match.v1.AddOcoRequest.Limit:type_name -> api.match.v1.Order
+	1,  // 4: api.match.v1.AddOcoRequest.Stop:type_name -> api.match.v1.Order
+	0,  // 5: api.match.v1.AddOcoReply.Result:type_name -> api.match.v1.ReplyResult
+	2,  // 6: api.match.v1.AddOcoReply.Trades:type_name -> api.match.v1.Trade
+	1,  // 7: api.match.v1.AddBracketRequest.Entry:type_name -> api.match.v1.Order
+	1,  // 8: api.match.v1.AddBracketRequest.Profit:type_name -> api.match.v1.Order
+	1,  // 9: api.match.v1.AddBracketRequest.Stop:type_name -> api.match.v1.Order
+	0,  // 10: api.match.v1.AddBracketReply.Result:type_name -> api.match.v1.ReplyResult
+	2,  // 11: api.match.v1.AddBracketReply.Trades:type_name -> api.match.v1.Trade
+	0,  // 12: api.match.v1.SyncPositionReply.Result:type_name -> api.match.v1.ReplyResult
+	0,  // 13: api.match.v1.CancelOrderReply.Result:type_name -> api.match.v1.ReplyResult
+	0,  // 14: api.match.v1.CancelAllReply.Result:type_name -> api.match.v1.ReplyResult
+	0,  // 15: api.match.v1.AmendOrderReply.Result:type_name -> api.match.v1.ReplyResult
+	2,  // 16: api.match.v1.AmendOrderReply.Trades:type_name -> api.match.v1.Trade
+	17, // 17: api.match.v1.Depth.bids:type_name -> api.match.v1.DepthLevel
+	17, // 18: api.match.v1.Depth.asks:type_name -> api.match.v1.DepthLevel
+	0,  // 19: api.match.v1.GetDepthReply.Result:type_name -> api.match.v1.ReplyResult
+	18, // 20: api.match.v1.GetDepthReply.Depth:type_name -> api.match.v1.Depth
+	21, // 21: api.match.v1.AddPairRequest.Pair:type_name -> api.match.v1.Pair
+	0,  // 22: api.match.v1.AddPairReply.Result:type_name -> api.match.v1.ReplyResult
+	0,  // 23: api.match.v1.HaltPairReply.Result:type_name -> api.match.v1.ReplyResult
+	0,  // 24: api.match.v1.ResumePairReply.Result:type_name -> api.match.v1.ReplyResult
+	0,  // 25: api.match.v1.DelistPairReply.Result:type_name -> api.match.v1.ReplyResult
+	0,  // 26: api.match.v1.StartAuctionReply.Result:type_name -> api.match.v1.ReplyResult
+	0,  // 27: api.match.v1.UncrossReply.Result:type_name -> api.match.v1.ReplyResult
+	3,  // 28: api.match.v1.MatchService.AddOrder:input_type -> api.match.v1.AddOrderRequest
+	11, // 29: api.match.v1.MatchService.CancelOrder:input_type -> api.match.v1.CancelOrderRequest
+	19, // 30: api.match.v1.MatchService.GetDepth:input_type -> api.match.v1.GetDepthRequest
+	15, // 31: api.match.v1.MatchService.AmendOrder:input_type -> api.match.v1.AmendOrderRequest
+	13, // 32: api.match.v1.MatchService.CancelAll:input_type -> api.match.v1.CancelAllRequest
+	5,  // 33: api.match.v1.MatchService.AddOco:input_type -> api.match.v1.AddOcoRequest
+	9,  // 34: api.match.v1.MatchService.SyncPosition:input_type -> api.match.v1.SyncPositionRequest
+	7,  // 35: api.match.v1.MatchService.AddBracket:input_type -> api.match.v1.AddBracketRequest
+	22, // 36: api.match.v1.AdminService.AddPair:input_type -> api.match.v1.AddPairRequest
+	24, // 37: api.match.v1.AdminService.HaltPair:input_type -> api.match.v1.HaltPairRequest
+	26, // 38: api.match.v1.AdminService.ResumePair:input_type -> api.match.v1.ResumePairRequest
+	28, // 39: api.match.v1.AdminService.DelistPair:input_type -> api.match.v1.DelistPairRequest
+	30, // 40: api.match.v1.AdminService.StartAuction:input_type -> api.match.v1.StartAuctionRequest
+	32, // 41: api.match.v1.AdminService.Uncross:input_type -> api.match.v1.UncrossRequest
+	4,  // 42: api.match.v1.MatchService.AddOrder:output_type -> api.match.v1.AddOrderReply
+	12, // 43: api.match.v1.MatchService.CancelOrder:output_type -> api.match.v1.CancelOrderReply
+	20, // 44: api.match.v1.MatchService.GetDepth:output_type -> api.match.v1.GetDepthReply
+	16, // 45: api.match.v1.MatchService.AmendOrder:output_type -> api.match.v1.AmendOrderReply
+	14, // 46: api.match.v1.MatchService.CancelAll:output_type -> api.match.v1.CancelAllReply
+	6,  // 47: api.match.v1.MatchService.AddOco:output_type -> api.match.v1.AddOcoReply
+	10, // 48: api.match.v1.MatchService.SyncPosition:output_type -> api.match.v1.SyncPositionReply
+	8,  // 49: api.match.v1.MatchService.AddBracket:output_type -> api.match.v1.AddBracketReply
+	23, // 50: api.match.v1.AdminService.AddPair:output_type -> api.match.v1.AddPairReply
+	25, // 51: api.match.v1.AdminService.HaltPair:output_type -> api.match.v1.HaltPairReply
+	27, // 52: api.match.v1.AdminService.ResumePair:output_type -> api.match.v1.ResumePairReply
+	29, // 53: api.match.v1.AdminService.DelistPair:output_type -> api.match.v1.DelistPairReply
+	31, // 54: api.match.v1.AdminService.StartAuction:output_type -> api.match.v1.StartAuctionReply
+	33, // 55: api.match.v1.AdminService.Uncross:output_type -> api.match.v1.UncrossReply
+	42, // [42:56] is the sub-list for method output_type
+	28, // [28:42] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
 }
 
 func init() { file_api_match_v1_match_proto_init() }
@@ -481,8 +2704,56 @@ func file_api_match_v1_match_proto_init() {
 				return nil
 			}
 		}
-		file_api_match_v1_match_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Order); i {
+		file_api_match_v1_match_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Order); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_match_v1_match_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Trade); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_match_v1_match_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddOrderRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_match_v1_match_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddOrderReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_match_v1_match_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddOcoRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -493,8 +2764,8 @@ func file_api_match_v1_match_proto_init() {
 				return nil
 			}
 		}
-		file_api_match_v1_match_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddOrderRequest); i {
+		file_api_match_v1_match_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddOcoReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -505,8 +2776,8 @@ func file_api_match_v1_match_proto_init() {
 				return nil
 			}
 		}
-		file_api_match_v1_match_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddOrderReply); i {
+		file_api_match_v1_match_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddBracketRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -517,7 +2788,43 @@ func file_api_match_v1_match_proto_init() {
 				return nil
 			}
 		}
-		file_api_match_v1_match_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_api_match_v1_match_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddBracketReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_match_v1_match_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncPositionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_match_v1_match_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncPositionReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_match_v1_match_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CancelOrderRequest); i {
 			case 0:
 				return &v.state
@@ -529,7 +2836,7 @@ func file_api_match_v1_match_proto_init() {
 				return nil
 			}
 		}
-		file_api_match_v1_match_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_api_match_v1_match_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CancelOrderReply); i {
 			case 0:
 				return &v.state
@@ -541,6 +2848,258 @@ func file_api_match_v1_match_proto_init() {
 				return nil
 			}
 		}
+		file_api_match_v1_match_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelAllRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_match_v1_match_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelAllReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_match_v1_match_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AmendOrderRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_match_v1_match_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AmendOrderReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_match_v1_match_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DepthLevel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_match_v1_match_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Depth); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_match_v1_match_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetDepthRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_match_v1_match_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetDepthReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_match_v1_match_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Pair); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_match_v1_match_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddPairRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_match_v1_match_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddPairReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_match_v1_match_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HaltPairRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_match_v1_match_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HaltPairReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_match_v1_match_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResumePairRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_match_v1_match_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResumePairReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_match_v1_match_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DelistPairRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_match_v1_match_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DelistPairReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_match_v1_match_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartAuctionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_match_v1_match_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartAuctionReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_match_v1_match_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UncrossRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_match_v1_match_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UncrossReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -548,9 +3107,9 @@ func file_api_match_v1_match_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_match_v1_match_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   34,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_api_match_v1_match_proto_goTypes,
 		DependencyIndexes: file_api_match_v1_match_proto_depIdxs,
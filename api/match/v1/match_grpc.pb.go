@@ -24,6 +24,12 @@ const _ = grpc.SupportPackageIsVersion7
 type MatchServiceClient interface {
 	AddOrder(ctx context.Context, in *AddOrderRequest, opts ...grpc.CallOption) (*AddOrderReply, error)
 	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderReply, error)
+	GetDepth(ctx context.Context, in *GetDepthRequest, opts ...grpc.CallOption) (*GetDepthReply, error)
+	AmendOrder(ctx context.Context, in *AmendOrderRequest, opts ...grpc.CallOption) (*AmendOrderReply, error)
+	CancelAll(ctx context.Context, in *CancelAllRequest, opts ...grpc.CallOption) (*CancelAllReply, error)
+	AddOco(ctx context.Context, in *AddOcoRequest, opts ...grpc.CallOption) (*AddOcoReply, error)
+	SyncPosition(ctx context.Context, in *SyncPositionRequest, opts ...grpc.CallOption) (*SyncPositionReply, error)
+	AddBracket(ctx context.Context, in *AddBracketRequest, opts ...grpc.CallOption) (*AddBracketReply, error)
 }
 
 type matchServiceClient struct {
@@ -52,12 +58,72 @@ func (c *matchServiceClient) CancelOrder(ctx context.Context, in *CancelOrderReq
 	return out, nil
 }
 
+func (c *matchServiceClient) GetDepth(ctx context.Context, in *GetDepthRequest, opts ...grpc.CallOption) (*GetDepthReply, error) {
+	out := new(GetDepthReply)
+	err := c.cc.Invoke(ctx, "/api.match.v1.MatchService/GetDepth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) AmendOrder(ctx context.Context, in *AmendOrderRequest, opts ...grpc.CallOption) (*AmendOrderReply, error) {
+	out := new(AmendOrderReply)
+	err := c.cc.Invoke(ctx, "/api.match.v1.MatchService/AmendOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) CancelAll(ctx context.Context, in *CancelAllRequest, opts ...grpc.CallOption) (*CancelAllReply, error) {
+	out := new(CancelAllReply)
+	err := c.cc.Invoke(ctx, "/api.match.v1.MatchService/CancelAll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) AddOco(ctx context.Context, in *AddOcoRequest, opts ...grpc.CallOption) (*AddOcoReply, error) {
+	out := new(AddOcoReply)
+	err := c.cc.Invoke(ctx, "/api.match.v1.MatchService/AddOco", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) SyncPosition(ctx context.Context, in *SyncPositionRequest, opts ...grpc.CallOption) (*SyncPositionReply, error) {
+	out := new(SyncPositionReply)
+	err := c.cc.Invoke(ctx, "/api.match.v1.MatchService/SyncPosition", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) AddBracket(ctx context.Context, in *AddBracketRequest, opts ...grpc.CallOption) (*AddBracketReply, error) {
+	out := new(AddBracketReply)
+	err := c.cc.Invoke(ctx, "/api.match.v1.MatchService/AddBracket", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MatchServiceServer is the server API for MatchService service.
 // All implementations must embed UnimplementedMatchServiceServer
 // for forward compatibility
 type MatchServiceServer interface {
 	AddOrder(context.Context, *AddOrderRequest) (*AddOrderReply, error)
 	CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderReply, error)
+	GetDepth(context.Context, *GetDepthRequest) (*GetDepthReply, error)
+	AmendOrder(context.Context, *AmendOrderRequest) (*AmendOrderReply, error)
+	CancelAll(context.Context, *CancelAllRequest) (*CancelAllReply, error)
+	AddOco(context.Context, *AddOcoRequest) (*AddOcoReply, error)
+	SyncPosition(context.Context, *SyncPositionRequest) (*SyncPositionReply, error)
+	AddBracket(context.Context, *AddBracketRequest) (*AddBracketReply, error)
 	mustEmbedUnimplementedMatchServiceServer()
 }
 
@@ -71,6 +137,24 @@ func (UnimplementedMatchServiceServer) AddOrder(context.Context, *AddOrderReques
 func (UnimplementedMatchServiceServer) CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
 }
+func (UnimplementedMatchServiceServer) GetDepth(context.Context, *GetDepthRequest) (*GetDepthReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDepth not implemented")
+}
+func (UnimplementedMatchServiceServer) AmendOrder(context.Context, *AmendOrderRequest) (*AmendOrderReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AmendOrder not implemented")
+}
+func (UnimplementedMatchServiceServer) CancelAll(context.Context, *CancelAllRequest) (*CancelAllReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelAll not implemented")
+}
+func (UnimplementedMatchServiceServer) AddOco(context.Context, *AddOcoRequest) (*AddOcoReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddOco not implemented")
+}
+func (UnimplementedMatchServiceServer) SyncPosition(context.Context, *SyncPositionRequest) (*SyncPositionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncPosition not implemented")
+}
+func (UnimplementedMatchServiceServer) AddBracket(context.Context, *AddBracketRequest) (*AddBracketReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddBracket not implemented")
+}
 func (UnimplementedMatchServiceServer) mustEmbedUnimplementedMatchServiceServer() {}
 
 // UnsafeMatchServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -120,6 +204,114 @@ func _MatchService_CancelOrder_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MatchService_GetDepth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDepthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).GetDepth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.match.v1.MatchService/GetDepth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).GetDepth(ctx, req.(*GetDepthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_AmendOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AmendOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).AmendOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.match.v1.MatchService/AmendOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).AmendOrder(ctx, req.(*AmendOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_CancelAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelAllRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).CancelAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.match.v1.MatchService/CancelAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).CancelAll(ctx, req.(*CancelAllRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_AddOco_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddOcoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).AddOco(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.match.v1.MatchService/AddOco",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).AddOco(ctx, req.(*AddOcoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_SyncPosition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncPositionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).SyncPosition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.match.v1.MatchService/SyncPosition",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).SyncPosition(ctx, req.(*SyncPositionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_AddBracket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddBracketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).AddBracket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.match.v1.MatchService/AddBracket",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).AddBracket(ctx, req.(*AddBracketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MatchService_ServiceDesc is the grpc.ServiceDesc for MatchService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -135,6 +327,296 @@ var MatchService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CancelOrder",
 			Handler:    _MatchService_CancelOrder_Handler,
 		},
+		{
+			MethodName: "GetDepth",
+			Handler:    _MatchService_GetDepth_Handler,
+		},
+		{
+			MethodName: "AmendOrder",
+			Handler:    _MatchService_AmendOrder_Handler,
+		},
+		{
+			MethodName: "CancelAll",
+			Handler:    _MatchService_CancelAll_Handler,
+		},
+		{
+			MethodName: "AddOco",
+			Handler:    _MatchService_AddOco_Handler,
+		},
+		{
+			MethodName: "SyncPosition",
+			Handler:    _MatchService_SyncPosition_Handler,
+		},
+		{
+			MethodName: "AddBracket",
+			Handler:    _MatchService_AddBracket_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/match/v1/match.proto",
+}
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AdminServiceClient interface {
+	AddPair(ctx context.Context, in *AddPairRequest, opts ...grpc.CallOption) (*AddPairReply, error)
+	HaltPair(ctx context.Context, in *HaltPairRequest, opts ...grpc.CallOption) (*HaltPairReply, error)
+	ResumePair(ctx context.Context, in *ResumePairRequest, opts ...grpc.CallOption) (*ResumePairReply, error)
+	DelistPair(ctx context.Context, in *DelistPairRequest, opts ...grpc.CallOption) (*DelistPairReply, error)
+	StartAuction(ctx context.Context, in *StartAuctionRequest, opts ...grpc.CallOption) (*StartAuctionReply, error)
+	Uncross(ctx context.Context, in *UncrossRequest, opts ...grpc.CallOption) (*UncrossReply, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) AddPair(ctx context.Context, in *AddPairRequest, opts ...grpc.CallOption) (*AddPairReply, error) {
+	out := new(AddPairReply)
+	err := c.cc.Invoke(ctx, "/api.match.v1.AdminService/AddPair", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) HaltPair(ctx context.Context, in *HaltPairRequest, opts ...grpc.CallOption) (*HaltPairReply, error) {
+	out := new(HaltPairReply)
+	err := c.cc.Invoke(ctx, "/api.match.v1.AdminService/HaltPair", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ResumePair(ctx context.Context, in *ResumePairRequest, opts ...grpc.CallOption) (*ResumePairReply, error) {
+	out := new(ResumePairReply)
+	err := c.cc.Invoke(ctx, "/api.match.v1.AdminService/ResumePair", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DelistPair(ctx context.Context, in *DelistPairRequest, opts ...grpc.CallOption) (*DelistPairReply, error) {
+	out := new(DelistPairReply)
+	err := c.cc.Invoke(ctx, "/api.match.v1.AdminService/DelistPair", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) StartAuction(ctx context.Context, in *StartAuctionRequest, opts ...grpc.CallOption) (*StartAuctionReply, error) {
+	out := new(StartAuctionReply)
+	err := c.cc.Invoke(ctx, "/api.match.v1.AdminService/StartAuction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) Uncross(ctx context.Context, in *UncrossRequest, opts ...grpc.CallOption) (*UncrossReply, error) {
+	out := new(UncrossReply)
+	err := c.cc.Invoke(ctx, "/api.match.v1.AdminService/Uncross", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+// All implementations must embed UnimplementedAdminServiceServer
+// for forward compatibility
+type AdminServiceServer interface {
+	AddPair(context.Context, *AddPairRequest) (*AddPairReply, error)
+	HaltPair(context.Context, *HaltPairRequest) (*HaltPairReply, error)
+	ResumePair(context.Context, *ResumePairRequest) (*ResumePairReply, error)
+	DelistPair(context.Context, *DelistPairRequest) (*DelistPairReply, error)
+	StartAuction(context.Context, *StartAuctionRequest) (*StartAuctionReply, error)
+	Uncross(context.Context, *UncrossRequest) (*UncrossReply, error)
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+// UnimplementedAdminServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedAdminServiceServer struct {
+}
+
+func (UnimplementedAdminServiceServer) AddPair(context.Context, *AddPairRequest) (*AddPairReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddPair not implemented")
+}
+func (UnimplementedAdminServiceServer) HaltPair(context.Context, *HaltPairRequest) (*HaltPairReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HaltPair not implemented")
+}
+func (UnimplementedAdminServiceServer) ResumePair(context.Context, *ResumePairRequest) (*ResumePairReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumePair not implemented")
+}
+func (UnimplementedAdminServiceServer) DelistPair(context.Context, *DelistPairRequest) (*DelistPairReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DelistPair not implemented")
+}
+func (UnimplementedAdminServiceServer) StartAuction(context.Context, *StartAuctionRequest) (*StartAuctionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartAuction not implemented")
+}
+func (UnimplementedAdminServiceServer) Uncross(context.Context, *UncrossRequest) (*UncrossReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Uncross not implemented")
+}
+func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
+
+// UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServiceServer will
+// result in compilation errors.
+type UnsafeAdminServiceServer interface {
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+func RegisterAdminServiceServer(s grpc.ServiceRegistrar, srv AdminServiceServer) {
+	s.RegisterService(&AdminService_ServiceDesc, srv)
+}
+
+func _AdminService_AddPair_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddPairRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).AddPair(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.match.v1.AdminService/AddPair",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).AddPair(ctx, req.(*AddPairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_HaltPair_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HaltPairRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).HaltPair(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.match.v1.AdminService/HaltPair",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).HaltPair(ctx, req.(*HaltPairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ResumePair_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumePairRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ResumePair(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.match.v1.AdminService/ResumePair",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ResumePair(ctx, req.(*ResumePairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DelistPair_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DelistPairRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DelistPair(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.match.v1.AdminService/DelistPair",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DelistPair(ctx, req.(*DelistPairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_StartAuction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartAuctionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).StartAuction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.match.v1.AdminService/StartAuction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).StartAuction(ctx, req.(*StartAuctionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_Uncross_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UncrossRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).Uncross(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.match.v1.AdminService/Uncross",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).Uncross(ctx, req.(*UncrossRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "api.match.v1.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddPair",
+			Handler:    _AdminService_AddPair_Handler,
+		},
+		{
+			MethodName: "HaltPair",
+			Handler:    _AdminService_HaltPair_Handler,
+		},
+		{
+			MethodName: "ResumePair",
+			Handler:    _AdminService_ResumePair_Handler,
+		},
+		{
+			MethodName: "DelistPair",
+			Handler:    _AdminService_DelistPair_Handler,
+		},
+		{
+			MethodName: "StartAuction",
+			Handler:    _AdminService_StartAuction_Handler,
+		},
+		{
+			MethodName: "Uncross",
+			Handler:    _AdminService_Uncross_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/match/v1/match.proto",
@@ -4,13 +4,15 @@ import (
 	"google.golang.org/grpc"
 	pb "lightning-engine/api/match/v1"
 	"lightning-engine/cmd/match"
+	"lightning-engine/internal/conf"
 	"log"
 	"net"
 )
 
 func main() {
-	pairs := []string{"BTC-USDT", "ETH-USDT"}
-	app, cleanup, err := match.WireApp(pairs)
+	pairs := []conf.Pair{{Symbol: "BTC-USDT"}, {Symbol: "ETH-USDT"}}
+	data := &conf.Data{Dir: "data", SnapshotInterval: 100000}
+	app, cleanup, err := match.WireApp(pairs, data)
 	if err != nil {
 		panic(err)
 	}
@@ -24,6 +26,7 @@ func main() {
 	}
 	grpcServer := grpc.NewServer()
 	pb.RegisterMatchServiceServer(grpcServer, app.Server)
+	pb.RegisterAdminServiceServer(grpcServer, app.AdminServer)
 	log.Println("[RPC] :8080")
 	grpcServer.Serve(lis)
 	select {}
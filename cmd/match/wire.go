@@ -5,12 +5,14 @@ package match
 
 import (
 	"github.com/google/wire"
+	"lightning-engine/internal/conf"
 	"lightning-engine/internal/match"
 	"lightning-engine/internal/server"
 	"lightning-engine/internal/status"
 	"lightning-engine/mq"
+	"lightning-engine/position"
 )
 
-func wireApp(pair []string) (*App, func(), error) {
-	panic(wire.Build(match.ProviderSet, server.ProviderSet, status.ProviderSet, mq.ProviderSet, newApp))
+func wireApp(pair []conf.Pair, data *conf.Data) (*App, func(), error) {
+	panic(wire.Build(match.ProviderSet, server.ProviderSet, status.ProviderSet, mq.ProviderSet, position.ProviderSet, newApp))
 }
@@ -7,37 +7,43 @@
 package match
 
 import (
+	"lightning-engine/internal/conf"
 	"lightning-engine/internal/match"
 	"lightning-engine/internal/server"
 	"lightning-engine/internal/status"
 	"lightning-engine/mq"
+	"lightning-engine/position"
 )
 
 type App struct {
 	status          *status.Status
 	Server          *server.Server
+	AdminServer     *server.AdminServer
 	SysSignalHandle *status.SysSignalHandle
 }
 
-func newApp(st *status.Status, se *server.Server, ss *status.SysSignalHandle) *App {
+func newApp(st *status.Status, se *server.Server, as *server.AdminServer, ss *status.SysSignalHandle) *App {
 	return &App{
 		status:          st,
 		Server:          se,
+		AdminServer:     as,
 		SysSignalHandle: ss,
 	}
 }
 
 // Injectors from wire.go:
-func WireApp(pair []string) (*App, func(), error) {
+func WireApp(pair []conf.Pair, data *conf.Data) (*App, func(), error) {
 	statusStatus := status.NewStatus()
 	imq := mq.NewYourMq()
-	matchPool, err := match.NewMatchPool(statusStatus, pair, imq)
+	iPosition := position.NewYourPosition()
+	matchPool, err := match.NewMatchPool(statusStatus, pair, imq, iPosition, data)
 	if err != nil {
 		return nil, nil, err
 	}
 	serverServer := server.NewServer(statusStatus, matchPool)
+	adminServer := server.NewAdminServer(matchPool)
 	sysSignalHandle := status.NewSysSignalHandle(statusStatus)
-	mainApp := newApp(statusStatus, serverServer, sysSignalHandle)
+	mainApp := newApp(statusStatus, serverServer, adminServer, sysSignalHandle)
 	return mainApp, func() {
 	}, nil
 }
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"lightning-engine/internal/snapshot"
+	"lightning-engine/models"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// 查看并校验盘口快照
+//
+//	go run ./cmd/snapshot -file data/BTC-USDT.00000000000000000100.snapshot
+//	go run ./cmd/snapshot -dir data -pair BTC-USDT -n 10
+func main() {
+	file := flag.String("file", "", "快照文件")
+	dir := flag.String("dir", "", "数据目录，与pair一起使用时查看该交易对最新的快照")
+	pair := flag.String("pair", "", "交易对")
+	n := flag.Int("n", 5, "打印的买卖盘订单数")
+	flag.Parse()
+
+	name := *file
+	if name == "" {
+		if *dir == "" || *pair == "" {
+			flag.Usage()
+			os.Exit(2)
+		}
+		seqs, err := snapshot.List(*dir, *pair)
+		if err != nil {
+			fail(err)
+		}
+		if len(seqs) == 0 {
+			fail(fmt.Errorf("no snapshot of %s in %s", *pair, *dir))
+		}
+		name = filepath.Join(*dir, snapshot.FileName(*pair, seqs[len(seqs)-1]))
+	}
+
+	s, err := snapshot.Read(name)
+	if err != nil {
+		fail(err)
+	}
+	fmt.Printf("file:     %s\n", name)
+	fmt.Printf("version:  %d\n", s.Header.Version)
+	fmt.Printf("pair:     %s\n", s.Header.Pair)
+	fmt.Printf("seq:      %d\n", s.Header.Seq)
+	fmt.Printf("time:     %s\n", time.UnixMilli(s.Header.Ts).Format(time.RFC3339Nano))
+	fmt.Printf("checksum: %08x\n", s.Header.Checksum)
+	fmt.Printf("bids:     %d\n", len(s.Bids))
+	fmt.Printf("asks:     %d\n", len(s.Asks))
+
+	fmt.Println("************** asks **************")
+	printOrders(s.Asks, *n)
+	fmt.Println("************** bids **************")
+	printOrders(s.Bids, *n)
+
+	if err := s.Verify(); err != nil {
+		fail(err)
+	}
+	fmt.Println("verify:   ok")
+}
+
+func printOrders(orders []models.Order, n int) {
+	for i, o := range orders {
+		if i >= n {
+			fmt.Printf("... %d more\n", len(orders)-n)
+			return
+		}
+		fmt.Printf("%s\t%s\tid:%s user:%d %s\n", o.Price, o.Amount, o.Id, o.UserId, o.TimeInForce)
+	}
+}
+
+func fail(err error) {
+	fmt.Println("verify:   failed,", err)
+	os.Exit(1)
+}
@@ -7,6 +7,7 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 	pb "lightning-engine/api/match/v1"
 	"lightning-engine/cmd/match"
+	"lightning-engine/internal/conf"
 	"log"
 	"net"
 	"time"
@@ -21,8 +22,9 @@ func main() {
 
 func server() {
 
-	pairs := []string{"BTC-USDT", "ETH-USDT"}
-	app, cleanup, err := match.WireApp(pairs)
+	pairs := []conf.Pair{{Symbol: "BTC-USDT"}, {Symbol: "ETH-USDT"}}
+	data := &conf.Data{}
+	app, cleanup, err := match.WireApp(pairs, data)
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,42 @@
+package conf
+
+import "github.com/shopspring/decimal"
+
+// 同一价格档位的分配算法
+const (
+	AllocationFifo    = "fifo"     // 价格优先、时间优先
+	AllocationProRata = "pro_rata" // 按订单剩余数量的比例分配
+	AllocationHybrid  = "hybrid"   // 时间最早的订单先按固定比例分配，剩余部分按比例分配
+)
+
+// Pair 交易对配置，数值为0时不限制
+type Pair struct {
+	Symbol  string          // 交易对名称
+	LotSize decimal.Decimal // 数量的最小变动单位，按金额下单的市价单成交数量向下取整到该单位
+
+	PriceBand decimal.Decimal // 市价单价格保护范围，相对到达时最优对手价的比例，如0.05表示5%
+
+	PriceTick   decimal.Decimal // 价格的最小变动单位
+	PriceScale  int32           // 价格的最大小数位数
+	AmountScale int32           // 数量的最大小数位数
+	MinAmount   decimal.Decimal // 单笔最小数量
+	MaxAmount   decimal.Decimal // 单笔最大数量
+	MinNotional decimal.Decimal // 单笔最小金额(价格*数量)，市价单按计价货币金额下单时检查金额
+	MaxNotional decimal.Decimal // 单笔最大金额
+
+	BreakerPercent  decimal.Decimal // 熔断阈值，成交价相对窗口内最高/最低成交价的波动超过该比例时熔断
+	BreakerWindow   int64           // 熔断窗口(毫秒)
+	BreakerDuration int64           // 熔断持续时间(毫秒)，到期后自动恢复，为0时需通过管理接口恢复
+	BreakerAuction  bool            // 熔断后进入波动性集合竞价，否则暂停交易
+
+	Allocation    string          // 同一价格档位的分配算法 fifo/pro_rata/hybrid，为空时为fifo
+	AllocationTop decimal.Decimal // hybrid算法中时间最早的订单优先分配taker数量的比例，0 < 比例 <= 1
+}
+
+// Data 数据持久化配置
+type Data struct {
+	Dir  string // 日志和快照存放目录，为空时不落盘，重启后盘口丢失
+	Sync bool   // 每条指令写入后是否立即fsync，开启后更安全但吞吐更低
+
+	SnapshotInterval int64 // 每处理多少条指令生成一次盘口快照，<=0时只在退出时生成
+}
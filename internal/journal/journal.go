@@ -0,0 +1,271 @@
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"io"
+	"lightning-engine/models"
+	"lightning-engine/utils"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 指令类型
+const (
+	OpAdd    = "add"    // 挂单
+	OpCancel = "cancel" // 撤单
+	OpAmend  = "amend"  // 改单
+
+	OpCancelAll = "cancel_all" // 批量撤单
+	OpExpire    = "expire"     // 撤销到期的GTD订单
+	OpHalt      = "halt"       // 暂停交易
+	OpResume    = "resume"     // 恢复交易
+	OpDelist    = "delist"     // 下架交易对
+	OpRelist    = "relist"     // 下架后重新上架，恢复连续撮合
+	OpAuction   = "auction"    // 开始集合竞价
+	OpUncross   = "uncross"    // 结束集合竞价，按均衡价成交后进入连续撮合
+	OpOco       = "oco"        // 挂OCO订单，Order为限价单，Link为止损单
+	OpPosition  = "position"   // 同步用户持仓，调整只减仓订单
+	OpBracket   = "bracket"    // 挂括号订单，Order为父订单，Profit为止盈单，Link为止损单
+)
+
+var ErrSeq = errors.New("journal sequence error")
+
+// Entry 日志条目，对应撮合协程处理的一条指令
+type Entry struct {
+	Seq   int64         `json:"s"`           // 序列号，单调递增
+	Op    string        `json:"o"`           // 指令类型
+	Order *models.Order `json:"d,omitempty"` // 挂单指令的订单，改单指令的订单id和新的价格、数量
+	Id    string        `json:"i,omitempty"` // 撤单指令的订单id
+	Ts    int64         `json:"ts"`          // 写入时间
+
+	UserId int64  `json:"u,omitempty"`  // 批量撤单指令的用户id，0表示全部用户；查询了持仓的指令为持仓所属的用户
+	Side   string `json:"sd,omitempty"` // 批量撤单指令的订单方向，为空表示买卖双方
+	Time   int64  `json:"t,omitempty"`  // 过期指令的当前时间(毫秒时间戳)，回放时按该时间判断订单是否到期
+
+	Link   *models.Order `json:"l,omitempty"`  // OCO和括号订单指令的止损单
+	Profit *models.Order `json:"pf,omitempty"` // 括号订单指令的止盈单
+
+	Position *decimal.Decimal `json:"ps,omitempty"` // 写入日志前查询的用户持仓，回放时使用该持仓，不再查询持仓接口
+}
+
+// Journal 单个交易对的预写日志，每行一条json格式的指令。
+// 当前写入的文件为<pair>.journal，Rotate后归档为<pair>.journal.<最后一条序列号>
+type Journal struct {
+	dir  string
+	pair string
+	file *os.File
+	seq  int64 // 最后写入的序列号
+	sync bool  // 每次写入后是否fsync
+}
+
+// Open 打开交易对的日志文件，不存在时创建。文件末尾不完整的条目(写入时崩溃)会被截断
+func Open(dir string, pair string, sync bool) (*Journal, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	j := &Journal{dir: dir, pair: pair, sync: sync}
+	segments, err := j.segments()
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) > 0 { // 当前文件从最后一个归档文件之后开始
+		j.seq = segments[len(segments)-1]
+	}
+	if err := j.open(); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// open 打开当前日志文件，并定位到最后一条完整条目之后
+func (j *Journal) open() error {
+	file, err := os.OpenFile(filepath.Join(j.dir, FileName(j.pair)), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+
+	// 找到最后一条完整条目的位置和序列号
+	offset, err := scan(file, func(e *Entry) error {
+		if e.Seq != j.seq+1 {
+			return ErrSeq
+		}
+		j.seq = e.Seq
+		return nil
+	})
+	if err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Truncate(offset); err != nil {
+		file.Close()
+		return err
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return err
+	}
+	j.file = file
+	return nil
+}
+
+// FileName 交易对对应的日志文件名
+func FileName(pair string) string {
+	return strings.ReplaceAll(pair, "/", "_") + ".journal"
+}
+
+// segmentName 归档日志文件名
+func segmentName(pair string, seq int64) string {
+	return fmt.Sprintf("%s.%020d", FileName(pair), seq)
+}
+
+// segments 按序列号升序返回所有归档文件的最后一条序列号
+func (j *Journal) segments() ([]int64, error) {
+	files, err := filepath.Glob(filepath.Join(j.dir, FileName(j.pair)+".*"))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]int64, 0, len(files))
+	for _, f := range files {
+		seq, err := strconv.ParseInt(strings.TrimPrefix(filepath.Base(f), FileName(j.pair)+"."), 10, 64)
+		if err != nil {
+			continue
+		}
+		result = append(result, seq)
+	}
+	sort.Slice(result, func(a, b int) bool { return result[a] < result[b] })
+	return result, nil
+}
+
+// Seq 最后写入的序列号
+func (j *Journal) Seq() int64 {
+	return j.seq
+}
+
+// Append 分配序列号并写入一条指令
+func (j *Journal) Append(e *Entry) error {
+	e.Seq = j.seq + 1
+	if e.Ts == 0 {
+		e.Ts = utils.NowUnixMilli()
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if _, err := j.file.Write(append(b, '\n')); err != nil {
+		return err
+	}
+	if j.sync {
+		if err := j.file.Sync(); err != nil {
+			return err
+		}
+	}
+	j.seq = e.Seq
+	return nil
+}
+
+// Replay 按顺序回放日志中序列号大于from的指令，跳过全部条目都不大于from的归档文件
+func (j *Journal) Replay(from int64, fn func(e *Entry) error) error {
+	filter := func(e *Entry) error {
+		if e.Seq <= from {
+			return nil
+		}
+		return fn(e)
+	}
+	segments, err := j.segments()
+	if err != nil {
+		return err
+	}
+	for _, seq := range segments {
+		if seq <= from {
+			continue
+		}
+		file, err := os.Open(filepath.Join(j.dir, segmentName(j.pair, seq)))
+		if err != nil {
+			return err
+		}
+		_, err = scan(file, filter)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+	_, err = scan(j.file, filter)
+	return err
+}
+
+// Rotate 归档当前日志文件并开始写入新文件，配合快照使用，恢复时只需回放快照之后的文件
+func (j *Journal) Rotate() error {
+	offset, err := j.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if offset == 0 { // 当前文件为空，无需归档
+		return nil
+	}
+	if err := j.file.Sync(); err != nil {
+		return err
+	}
+	if err := j.file.Close(); err != nil {
+		return err
+	}
+	err = os.Rename(filepath.Join(j.dir, FileName(j.pair)), filepath.Join(j.dir, segmentName(j.pair, j.seq)))
+	if err != nil {
+		return err
+	}
+	return j.open()
+}
+
+// Purge 删除最后一条序列号不大于seq的归档文件
+func (j *Journal) Purge(seq int64) error {
+	segments, err := j.segments()
+	if err != nil {
+		return err
+	}
+	for _, s := range segments {
+		if s > seq {
+			break
+		}
+		if err := os.Remove(filepath.Join(j.dir, segmentName(j.pair, s))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close 关闭日志文件
+func (j *Journal) Close() error {
+	if j.sync {
+		j.file.Sync()
+	}
+	return j.file.Close()
+}
+
+// scan 从头读取文件中所有完整条目，返回最后一条完整条目结束的位置
+func scan(file *os.File, fn func(e *Entry) error) (int64, error) {
+	r := bufio.NewReader(io.NewSectionReader(file, 0, 1<<62))
+	var offset int64
+	for {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF { // 最后一行没有换行符，说明写入不完整
+			return offset, nil
+		}
+		if err != nil {
+			return offset, err
+		}
+		e := &Entry{}
+		if err := json.Unmarshal(line, e); err != nil {
+			return offset, err
+		}
+		if err := fn(e); err != nil {
+			return offset, err
+		}
+		offset += int64(len(line))
+	}
+}
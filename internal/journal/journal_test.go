@@ -0,0 +1,91 @@
+package journal
+
+import (
+	"github.com/shopspring/decimal"
+	"lightning-engine/models"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+const pair = "BTC/USDT"
+
+func TestJournal_AppendReplay(t *testing.T) {
+	dir := t.TempDir()
+	j, err := Open(dir, pair, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		e := &Entry{Op: OpAdd, Order: &models.Order{Id: strconv.Itoa(i), Pair: pair, Amount: decimal.NewFromInt(int64(i))}}
+		if err := j.Append(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := j.Append(&Entry{Op: OpCancel, Id: "1"}); err != nil {
+		t.Fatal(err)
+	}
+	j.Close()
+
+	// 重新打开后序列号继续递增
+	j, err = Open(dir, pair, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j.Close()
+	if j.Seq() != 11 {
+		t.Fatalf("seq error: %d", j.Seq())
+	}
+
+	var seq int64 = 5
+	err = j.Replay(5, func(e *Entry) error {
+		seq++
+		if e.Seq != seq {
+			t.Errorf("replay seq error, want %d got %d", seq, e.Seq)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seq != 11 {
+		t.Errorf("replay count error: %d", seq)
+	}
+}
+
+func TestJournal_TruncateTail(t *testing.T) {
+	dir := t.TempDir()
+	j, err := Open(dir, pair, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	j.Append(&Entry{Op: OpCancel, Id: "1"})
+	j.Append(&Entry{Op: OpCancel, Id: "2"})
+	j.Close()
+
+	// 模拟写入一半时崩溃
+	f, _ := os.OpenFile(filepath.Join(dir, FileName(pair)), os.O_APPEND|os.O_WRONLY, 0644)
+	f.WriteString(`{"s":3,"o":"can`)
+	f.Close()
+
+	j, err = Open(dir, pair, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j.Close()
+	if j.Seq() != 2 {
+		t.Fatalf("seq error: %d", j.Seq())
+	}
+	if err := j.Append(&Entry{Op: OpCancel, Id: "3"}); err != nil {
+		t.Fatal(err)
+	}
+	count := 0
+	j.Replay(0, func(e *Entry) error {
+		count++
+		return nil
+	})
+	if count != 3 {
+		t.Errorf("replay count error: %d", count)
+	}
+}
@@ -0,0 +1,72 @@
+package match
+
+import (
+	"github.com/shopspring/decimal"
+	"lightning-engine/internal/conf"
+	"lightning-engine/models"
+	"lightning-engine/pqueue/skiplist"
+)
+
+// allocate 在first所在的价格档位内按比例分配taker的剩余数量，返回成交单。
+// hybrid先按AllocationTop比例分配给时间最早的订单，剩余部分按各订单剩余数量的比例分配；
+// 分配数量向下取整到LotSize，取整剩下的数量按时间优先每次分配一个LotSize，保证结果确定。
+// 全部成交订单不参与分配；taker可以吃完当前档位或档位中有自成交的订单时返回nil，按时间优先撮合
+func (ob *Orderbook) allocate(order *models.Order, makers book, index map[string]decimal.Decimal, first *skiplist.SkipListNode) []models.Trade {
+	price := first.Score()
+	nodes := make([]*skiplist.SkipListNode, 0)
+	total := decimal.Zero
+	for p := first; p != nil && p.Score().Equal(price); p = p.Next(0) {
+		maker := p.Value().(*models.Order)
+		if order.Stp != "" && maker.UserId == order.UserId {
+			return nil
+		}
+		if maker.AllOrNone {
+			continue
+		}
+		nodes = append(nodes, p)
+		total = total.Add(maker.Amount)
+	}
+	if order.Amount.GreaterThanOrEqual(total) {
+		return nil
+	}
+
+	step := ob.lot()
+	amount := order.Amount
+	fills := make([]decimal.Decimal, len(nodes))
+	if ob.spec.Allocation == conf.AllocationHybrid { // 时间最早的订单优先分配
+		fills[0] = decimal.Min(amount.Mul(ob.spec.AllocationTop).Div(step).Floor().Mul(step), nodes[0].Value().GetAmount())
+		amount = amount.Sub(fills[0])
+		total = total.Sub(fills[0])
+	}
+
+	// 按剩余数量的比例分配
+	base := amount
+	for i, node := range nodes {
+		share := base.Mul(node.Value().GetAmount().Sub(fills[i])).Div(total).Div(step).Floor().Mul(step)
+		fills[i] = fills[i].Add(share)
+		amount = amount.Sub(share)
+	}
+
+	// 取整剩下的数量按时间优先分配
+	for progressed := true; amount.IsPositive() && progressed; {
+		progressed = false
+		for i, node := range nodes {
+			room := node.Value().GetAmount().Sub(fills[i])
+			if !amount.IsPositive() || !room.IsPositive() {
+				continue
+			}
+			share := decimal.Min(step, amount, room)
+			fills[i] = fills[i].Add(share)
+			amount = amount.Sub(share)
+			progressed = true
+		}
+	}
+
+	trades := make([]models.Trade, 0)
+	for i, node := range nodes {
+		if fills[i].IsPositive() {
+			trades = append(trades, ob.fill(order, makers, index, node, fills[i]))
+		}
+	}
+	return trades
+}
@@ -0,0 +1,125 @@
+package match
+
+import (
+	"github.com/shopspring/decimal"
+	"lightning-engine/internal/journal"
+	"lightning-engine/models"
+	"time"
+)
+
+// Amend 同步改单，price、amount为0时不修改。amount为新的剩余数量，冰山单包括隐藏数量。
+// 只减少数量时保持时间优先级，修改价格或增加数量时重新排队，新价格可以成交时立即撮合
+func (ob *Orderbook) Amend(id string, price decimal.Decimal, amount decimal.Decimal) ([]models.Trade, error) {
+	if id == "" {
+		return nil, ErrOrderId
+	}
+	if price.IsNegative() {
+		return nil, ErrOrderPrice
+	}
+	if amount.IsNegative() || price.IsZero() && amount.IsZero() {
+		return nil, ErrOrderAmount
+	}
+	if err := ob.checkPrice(price); err != nil {
+		return nil, err
+	}
+	if err := ob.checkAmount(amount); err != nil {
+		return nil, err
+	}
+	ob.status.Add(1)
+	defer ob.status.Done()
+	req := addRequest{order: models.Order{Id: id, Pair: ob.pair, Price: price, Amount: amount}, reply: make(chan addResult, 1)}
+	if err := ob.submit(func() error {
+		select {
+		case ob.chAmend <- req:
+			return nil
+		case <-time.After(time.Second):
+			return ErrTimeout
+		case <-ob.status.Context().Done():
+			return ErrClosed
+		}
+	}); err != nil {
+		return nil, err
+	}
+	select {
+	case result := <-req.reply:
+		return result.trades, result.err
+	case <-time.After(time.Second):
+		return nil, ErrTimeout
+	case <-ob.status.Context().Done():
+		return nil, ErrClosed
+	}
+}
+
+// handleAmend 处理改单请求
+func (ob *Orderbook) handleAmend(req addRequest) {
+	if _, err := ob.tradable(); err != nil {
+		req.reply <- addResult{err: err}
+		return
+	}
+	trades, err := ob.handle(&journal.Entry{Op: journal.OpAmend, Order: &req.order})
+	req.reply <- addResult{trades: trades, err: err}
+}
+
+// amend 修改盘口中的限价单，返回改单事件和重新挂单产生的成交单
+func (ob *Orderbook) amend(change models.Order) ([]models.Trade, error) {
+	if score, ok := ob.mBid[change.Id]; ok {
+		return ob.amendOrder(ob.bid, ob.mBid, score, change)
+	} else if score, ok := ob.mAsk[change.Id]; ok {
+		return ob.amendOrder(ob.ask, ob.mAsk, score, change)
+	}
+	return nil, ErrOrderId
+}
+
+// amendOrder 修改一侧盘口中的订单
+func (ob *Orderbook) amendOrder(makers book, index map[string]decimal.Decimal, score decimal.Decimal, change models.Order) ([]models.Trade, error) {
+	node, _ := makers.Find(score, change.Id)
+	if node == nil {
+		return nil, ErrOrderId
+	}
+	order := node.Value().(*models.Order)
+	if order.Peg != "" && change.Price.IsPositive() { // 挂钩单的价格由撮合引擎维护
+		return nil, ErrPeg
+	}
+	price, amount := order.Price, order.TotalAmount()
+	if change.Price.IsPositive() {
+		price = change.Price
+	}
+	if change.Amount.IsPositive() {
+		amount = change.Amount
+	}
+
+	// 按修改后的订单重新校验
+	amended := *order
+	amended.Price = price
+	amended.Amount = amount
+	amended.Hidden = decimal.Zero
+	if err := ob.checkAmend(&amended); err != nil {
+		return nil, err
+	}
+
+	// 价格不变且数量减少，原地修改，先减少冰山单的隐藏数量
+	if price.Equal(order.Price) && amount.LessThanOrEqual(order.TotalAmount()) {
+		reduce := order.TotalAmount().Sub(amount)
+		hidden := decimal.Min(reduce, order.Hidden)
+		order.Hidden = order.Hidden.Sub(hidden)
+		order.SetAmount(order.Amount.Sub(reduce.Sub(hidden)))
+		return []models.Trade{eventTrade(order, models.Amend, amount, "")}, nil
+	}
+
+	// 修改价格或增加数量，删除后按新的价格和数量重新挂单
+	remove(makers, index, node)
+	trades := []models.Trade{eventTrade(&amended, models.Amend, amount, "")}
+	added, err := ob.add(amended)
+	return append(trades, added...), err
+}
+
+// checkAmend 按修改后的价格和数量校验交易对规格，冰山单显示数量要小于新的数量，最小成交数量不能超过新的数量
+func (ob *Orderbook) checkAmend(order *models.Order) error {
+	if order.IsIceberg() && order.DisplayAmount.GreaterThanOrEqual(order.Amount) {
+		return ErrDisplayAmount
+	}
+	if order.MinQty.GreaterThan(order.Amount) {
+		return ErrMinQty
+	}
+	return ob.check(order)
+}
@@ -0,0 +1,217 @@
+package match
+
+import (
+	"github.com/shopspring/decimal"
+	"lightning-engine/models"
+	"lightning-engine/pqueue/skiplist"
+	"lightning-engine/utils"
+)
+
+// addAuction 集合竞价阶段挂单，GTC/GTD限价单直接挂在盘口不撮合，止损单放入触发簿，其他订单(包括挂钩单)拒绝
+func (ob *Orderbook) addAuction(order models.Order) ([]models.Trade, error) {
+	if order.IsStop() {
+		if order.Side == models.Buy {
+			return ob.addBidStop(order)
+		}
+		return ob.addAskStop(order)
+	}
+	if order.Type != models.Limit || order.TimeInForce != models.TimeInForceGTC && order.TimeInForce != models.TimeInForceGTD || order.Peg != "" {
+		return []models.Trade{cancelTrade(&order, models.ReasonAuction)}, nil
+	}
+	ob.schedule(&order)
+	split(&order)
+	if order.Side == models.Buy {
+		ob.bid.Insert(order.Price, &order)
+		ob.mBid[order.Id] = order.Price
+	} else {
+		ob.ask.Insert(order.Price, &order)
+		ob.mAsk[order.Id] = order.Price
+	}
+	return nil, nil
+}
+
+// equilibrium 计算集合竞价均衡价，返回均衡价和可成交数量，没有可成交的订单时返回0。
+// 取成交量最大的价格；成交量相同时取未成交量最小的价格；仍相同时买方剩余取最高价，卖方剩余取最低价；
+// 仍无法确定时取最接近最新成交价的价格，没有最新成交价时取最低价
+func (ob *Orderbook) equilibrium() (decimal.Decimal, decimal.Decimal) {
+	bids, asks := levels(ob.bid.First()), levels(ob.ask.First())
+	if len(bids) == 0 || len(asks) == 0 || bids[0].price.LessThan(asks[0].price) {
+		return decimal.Zero, decimal.Zero
+	}
+
+	// 候选价格为买卖双方交叉区间内的全部挂单价格
+	prices := make([]decimal.Decimal, 0)
+	for _, l := range bids {
+		if l.price.GreaterThanOrEqual(asks[0].price) {
+			prices = append(prices, l.price)
+		}
+	}
+	for _, l := range asks {
+		if l.price.LessThanOrEqual(bids[0].price) {
+			prices = append(prices, l.price)
+		}
+	}
+
+	type candidate struct {
+		price, volume, surplus decimal.Decimal // surplus为买方数量-卖方数量
+	}
+	var best []candidate
+	for _, p := range prices {
+		buy, sell := decimal.Zero, decimal.Zero
+		for _, l := range bids {
+			if l.price.GreaterThanOrEqual(p) {
+				buy = buy.Add(l.amount)
+			}
+		}
+		for _, l := range asks {
+			if l.price.LessThanOrEqual(p) {
+				sell = sell.Add(l.amount)
+			}
+		}
+		c := candidate{price: p, volume: decimal.Min(buy, sell), surplus: buy.Sub(sell)}
+		switch {
+		case len(best) == 0 || c.volume.GreaterThan(best[0].volume) ||
+			c.volume.Equal(best[0].volume) && c.surplus.Abs().LessThan(best[0].surplus.Abs()):
+			best = []candidate{c}
+		case c.volume.Equal(best[0].volume) && c.surplus.Abs().Equal(best[0].surplus.Abs()) && !c.price.Equal(best[0].price):
+			best = append(best, c)
+		}
+	}
+
+	// 按市场压力和参考价选择
+	choose := best[0]
+	buyPressure, sellPressure := true, true
+	for _, c := range best {
+		buyPressure = buyPressure && c.surplus.IsPositive()
+		sellPressure = sellPressure && c.surplus.IsNegative()
+	}
+	for _, c := range best[1:] {
+		switch {
+		case buyPressure:
+			if c.price.GreaterThan(choose.price) {
+				choose = c
+			}
+		case sellPressure || ob.lastPrice.IsZero():
+			if c.price.LessThan(choose.price) {
+				choose = c
+			}
+		default:
+			d, cd := choose.price.Sub(ob.lastPrice).Abs(), c.price.Sub(ob.lastPrice).Abs()
+			if cd.LessThan(d) || cd.Equal(d) && c.price.LessThan(choose.price) {
+				choose = c
+			}
+		}
+	}
+	return choose.price, choose.volume
+}
+
+// level 价格档位的总数量，冰山单包括隐藏数量
+type level struct {
+	price  decimal.Decimal
+	amount decimal.Decimal
+}
+
+// levels 从first开始按价格聚合全部档位
+func levels(first *skiplist.SkipListNode) []level {
+	result := make([]level, 0)
+	for p := first; p != nil; p = p.Next(0) {
+		amount := p.Value().(*models.Order).TotalAmount()
+		if n := len(result); n > 0 && result[n-1].price.Equal(p.Score()) {
+			result[n-1].amount = result[n-1].amount.Add(amount)
+		} else {
+			result = append(result, level{price: p.Score(), amount: amount})
+		}
+	}
+	return result
+}
+
+// uncross 结束集合竞价，所有可成交的订单按均衡价成交，买卖双方按价格优先、时间优先排列，成交单的taker为买方
+func (ob *Orderbook) uncross() []models.Trade {
+	trades := make([]models.Trade, 0)
+	price, _ := ob.equilibrium()
+	for !price.IsZero() && ob.bid.First() != nil && ob.ask.First() != nil &&
+		ob.bid.First().Score().GreaterThanOrEqual(price) && ob.ask.First().Score().LessThanOrEqual(price) {
+		bidNode, askNode := ob.bid.First(), ob.ask.First()
+		if stp := ob.auctionSelfTrade(bidNode, askNode); stp != nil {
+			trades = append(trades, stp...)
+			continue
+		}
+		bid, ask := bidNode.Value().(*models.Order), askNode.Value().(*models.Order)
+		amount := decimal.Min(bid.Amount, ask.Amount)
+		trades = append(trades, models.Trade{
+			Id:               utils.GenTradeId(),
+			Pair:             ob.pair,
+			MakerId:          ask.Id,
+			TakerId:          bid.Id,
+			MakerUser:        ask.UserId,
+			TakerUser:        bid.UserId,
+			Price:            price.String(),
+			Amount:           amount.String(),
+			TakerOrderSide:   bid.Side,
+			TakerOrderType:   bid.Type,
+			TakerTimeInForce: bid.TimeInForce,
+			Ts:               utils.NowUnixMilli(),
+		})
+		bid.SetAmount(bid.Amount.Sub(amount))
+		ask.SetAmount(ask.Amount.Sub(amount))
+		ob.record(price)
+		if !bid.Amount.IsPositive() && !replenish(ob.bid, bidNode) {
+			remove(ob.bid, ob.mBid, bidNode)
+		}
+		if !ask.Amount.IsPositive() && !replenish(ob.ask, askNode) {
+			remove(ob.ask, ob.mAsk, askNode)
+		}
+	}
+	trades = append(trades, ob.unlinkTrades(trades)...)
+	ob.breaker.until = 0
+	ob.setPhase(models.PhaseContinuous, "")
+	ob.setLastPrice(trades)
+	trades = append(trades, ob.trail(trades)...)
+	trades = append(trades, ob.trigger()...)
+	if len(trades) > 0 {
+		ob.PushTrades(trades...)
+	}
+	return trades
+}
+
+// auctionSelfTrade 集合竞价撮合的买卖双方为同一用户时按自成交保护处理，返回nil时允许自成交。
+// 买方设置了自成交保护时买方作为taker，否则卖方作为taker；taker在盘口中，撤销时连同冰山单隐藏数量一起撤销
+func (ob *Orderbook) auctionSelfTrade(bidNode *skiplist.SkipListNode, askNode *skiplist.SkipListNode) []models.Trade {
+	bid, ask := bidNode.Value().(*models.Order), askNode.Value().(*models.Order)
+	if bid.UserId != ask.UserId || bid.Stp == "" && ask.Stp == "" {
+		return nil
+	}
+	var takers, makers book = ob.bid, ob.ask
+	taker, takerNode, takerIndex := bid, bidNode, ob.mBid
+	makerNode, makerIndex := askNode, ob.mAsk
+	if bid.Stp == "" {
+		takers, makers = ob.ask, ob.bid
+		taker, takerNode, takerIndex = ask, askNode, ob.mAsk
+		makerNode, makerIndex = bidNode, ob.mBid
+	}
+	if taker.Stp == models.StpCancelNewest || taker.Stp == models.StpCancelBoth {
+		taker.Amount, taker.Hidden = taker.TotalAmount(), decimal.Zero
+	}
+	trades := ob.selfTrade(taker, makerNode, makers, makerIndex)
+	if !taker.Amount.IsPositive() && !replenish(takers, takerNode) {
+		remove(takers, takerIndex, takerNode)
+	}
+	return trades
+}
+
+// indicate 推送集合竞价参考价和可成交数量
+func (ob *Orderbook) indicate() {
+	price, volume := ob.equilibrium()
+	event := models.Event{
+		Id:     utils.GenTradeId(),
+		Pair:   ob.pair,
+		Type:   models.EventAuction,
+		Phase:  ob.phase,
+		Amount: volume.String(),
+		Ts:     utils.NowUnixMilli(),
+	}
+	if !price.IsZero() {
+		event.Price = price.String()
+	}
+	ob.PushEvents(event)
+}
@@ -0,0 +1,200 @@
+package match
+
+import (
+	"github.com/shopspring/decimal"
+	"lightning-engine/internal/snapshot"
+	"lightning-engine/models"
+	"sort"
+	"time"
+)
+
+// bracket 括号订单，父订单成交后按成交数量激活止盈单和止损单，子订单之间为OCO
+type bracket struct {
+	parent string       // 父订单id
+	profit models.Order // 止盈单，激活前数量无效
+	stop   models.Order // 止损单，激活前数量无效
+	active bool         // 子订单是否已激活
+}
+
+// AddBracket 同步挂括号订单，entry为父订单(限价单)，profit为止盈限价单(GTC/GTD)，stop为止损单，返回立即成交的成交单。
+// 父订单第一次成交时按成交数量激活子订单，之后每次成交增加子订单的数量；子订单成交、触发或撤销时撤销父订单剩余部分
+func (ob *Orderbook) AddBracket(entry *models.Order, profit *models.Order, stop *models.Order) ([]models.Trade, error) {
+	tp, sl := *profit, *stop
+	tp.Amount, sl.Amount = entry.Amount, entry.Amount // 子订单数量不超过父订单，按父订单数量校验
+	for _, order := range []*models.Order{entry, &tp, &sl} {
+		if err := validate(order); err != nil {
+			return nil, err
+		}
+		if err := ob.check(order); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkBracket(entry, &tp, &sl); err != nil {
+		return nil, err
+	}
+	ob.status.Add(1)
+	defer ob.status.Done()
+	req := addRequest{order: *entry, link: &sl, profit: &tp, reply: make(chan addResult, 1)}
+	if err := ob.submit(func() error {
+		select {
+		case ob.chAdd <- req:
+			return nil
+		case <-time.After(time.Second):
+			return ErrTimeout
+		case <-ob.status.Context().Done():
+			return ErrClosed
+		}
+	}); err != nil {
+		return nil, err
+	}
+	select {
+	case result := <-req.reply:
+		return result.trades, result.err
+	case <-time.After(time.Second):
+		return nil, ErrTimeout
+	case <-ob.status.Context().Done():
+		return nil, ErrClosed
+	}
+}
+
+// checkBracket 校验括号订单，子订单与父订单方向相反，止盈单和止损单按OCO校验
+func checkBracket(entry *models.Order, profit *models.Order, stop *models.Order) error {
+	if entry.Type != models.Limit || entry.Peg != "" || entry.Side == profit.Side || entry.Id == profit.Id || entry.Id == stop.Id ||
+		entry.Pair != profit.Pair || entry.UserId != profit.UserId {
+		return ErrBracket
+	}
+	for _, order := range []*models.Order{entry, profit, stop} {
+		if order.IsReduceOnly() || order.LinkId != "" {
+			return ErrBracket
+		}
+	}
+	if err := checkOco(profit, stop); err != nil {
+		return ErrBracket
+	}
+	return nil
+}
+
+// addBracket 记录括号订单后挂父订单，父订单的成交由settle处理
+func (ob *Orderbook) addBracket(entry models.Order, profit models.Order, stop models.Order) ([]models.Trade, error) {
+	b := &bracket{parent: entry.Id, profit: profit, stop: stop}
+	ob.brackets[entry.Id], ob.brackets[profit.Id], ob.brackets[stop.Id] = b, b, b
+	return ob.add(entry)
+}
+
+// settle 处理成交单中的括号订单，以及激活子订单引起的成交和止损单触发，推送并返回产生的记录
+func (ob *Orderbook) settle(trades []models.Trade) []models.Trade {
+	result := make([]models.Trade, 0)
+	for pending := trades; len(ob.brackets) > 0 && len(pending) > 0; {
+		created := ob.bracketTrades(pending)
+		if len(created) == 0 {
+			break
+		}
+		ob.setLastPrice(created)
+		follow := ob.trail(created)
+		follow = append(follow, ob.trigger()...)
+		created = append(created, follow...)
+		ob.PushTrades(created...)
+		result = append(result, created...)
+		pending = follow
+	}
+	return result
+}
+
+// bracketTrades 父订单成交时激活或增加子订单，子订单结束时撤销父订单剩余部分，产生的记录继续按顺序处理
+func (ob *Orderbook) bracketTrades(trades []models.Trade) []models.Trade {
+	result := make([]models.Trade, 0)
+	queue := append([]models.Trade{}, trades...)
+	for len(queue) > 0 && len(ob.brackets) > 0 {
+		trade := queue[0]
+		queue = queue[1:]
+		if trade.TakerOrderType == models.Amend || trade.TakerOrderType == models.Trail { // 改单和跟踪止损更新不影响括号订单
+			continue
+		}
+		created := ob.bracketTrade(trade, trade.MakerId)
+		if trade.TakerId != trade.MakerId { // 撤销、触发等事件记录的maker和taker为同一订单
+			created = append(created, ob.bracketTrade(trade, trade.TakerId)...)
+		}
+		result = append(result, created...)
+		queue = append(queue, created...)
+	}
+	return result
+}
+
+// bracketTrade 处理成交单中id对应的括号订单
+func (ob *Orderbook) bracketTrade(trade models.Trade, id string) []models.Trade {
+	b, ok := ob.brackets[id]
+	if !ok {
+		return nil
+	}
+	if id == b.parent {
+		if trade.IsFill() {
+			return ob.activate(b, decimal.RequireFromString(trade.Amount))
+		}
+		if !b.active && ob.locate(id) == nil { // 父订单未成交就被撤销或拒绝
+			ob.closeBracket(b)
+		}
+		return nil
+	}
+
+	// 子订单成交、触发或撤销，撤销父订单剩余部分
+	ob.closeBracket(b)
+	s := ob.locate(b.parent)
+	if s == nil {
+		return nil
+	}
+	node, _ := s.orders.Find(s.index[b.parent], b.parent)
+	if node == nil {
+		return nil
+	}
+	trades := []models.Trade{cancelTrade(node.Value().(*models.Order), models.ReasonBracket)}
+	remove(s.orders, s.index, node)
+	return trades
+}
+
+// activate 父订单成交amount数量，第一次成交时按成交数量挂止盈单和止损单，之后原地增加子订单的数量
+func (ob *Orderbook) activate(b *bracket, amount decimal.Decimal) []models.Trade {
+	if !b.active {
+		b.active = true
+		profit, stop := b.profit, b.stop
+		profit.Amount, stop.Amount = amount, amount
+		trades, _ := ob.addOco(profit, stop)
+		return trades
+	}
+	trades := make([]models.Trade, 0)
+	for _, id := range []string{b.profit.Id, b.stop.Id} {
+		s := ob.locate(id)
+		if s == nil {
+			continue
+		}
+		if node, _ := s.orders.Find(s.index[id], id); node != nil {
+			order := node.Value().(*models.Order)
+			order.SetAmount(order.Amount.Add(amount))
+			trades = append(trades, eventTrade(order, models.Amend, order.TotalAmount(), models.ReasonBracket))
+		}
+	}
+	return trades
+}
+
+// cancelBracket 撤单指令撤销了括号订单的父订单或子订单，撤单记录不在指令返回的成交单中，单独处理
+func (ob *Orderbook) cancelBracket(id string) []models.Trade {
+	return ob.bracketTrade(models.Trade{MakerId: id, TakerId: id, TakerOrderType: models.Cancel}, id)
+}
+
+// closeBracket 子订单结束后删除括号订单，之后子订单只按OCO处理
+func (ob *Orderbook) closeBracket(b *bracket) {
+	delete(ob.brackets, b.parent)
+	delete(ob.brackets, b.profit.Id)
+	delete(ob.brackets, b.stop.Id)
+}
+
+// snapshotBrackets 父订单还在盘口中的括号订单，按父订单id排列
+func (ob *Orderbook) snapshotBrackets() []snapshot.Bracket {
+	brackets := make([]snapshot.Bracket, 0)
+	for id, b := range ob.brackets {
+		if id == b.parent && ob.locate(id) != nil {
+			brackets = append(brackets, snapshot.Bracket{Parent: b.parent, Profit: b.profit, Stop: b.stop, Active: b.active})
+		}
+	}
+	sort.Slice(brackets, func(i, j int) bool { return brackets[i].Parent < brackets[j].Parent })
+	return brackets
+}
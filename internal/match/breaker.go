@@ -0,0 +1,115 @@
+package match
+
+import (
+	"github.com/shopspring/decimal"
+	"lightning-engine/internal/journal"
+	"lightning-engine/internal/snapshot"
+	"lightning-engine/models"
+)
+
+// breaker 熔断状态，用单调队列维护窗口内的最低和最高成交价
+type breaker struct {
+	lows  []snapshot.PricePoint // 成交价单调递增，队首为窗口内最低价
+	highs []snapshot.PricePoint // 成交价单调递减，队首为窗口内最高价
+	until int64                 // 熔断结束时间(毫秒时间戳)，0表示未熔断或需手动恢复
+}
+
+// enabled 交易对是否配置了熔断
+func (ob *Orderbook) enabled() bool {
+	return ob.spec.BreakerPercent.IsPositive() && ob.spec.BreakerWindow > 0
+}
+
+// prune 删除窗口之外的成交价
+func (ob *Orderbook) prune() {
+	from := ob.now - ob.spec.BreakerWindow
+	for len(ob.breaker.lows) > 0 && ob.breaker.lows[0].Ts < from {
+		ob.breaker.lows = ob.breaker.lows[1:]
+	}
+	for len(ob.breaker.highs) > 0 && ob.breaker.highs[0].Ts < from {
+		ob.breaker.highs = ob.breaker.highs[1:]
+	}
+}
+
+// record 记录成交价
+func (ob *Orderbook) record(price decimal.Decimal) {
+	if !ob.enabled() {
+		return
+	}
+	ob.prune()
+	point := snapshot.PricePoint{Ts: ob.now, Price: price}
+	for n := len(ob.breaker.lows); n > 0 && ob.breaker.lows[n-1].Price.GreaterThanOrEqual(price); n-- {
+		ob.breaker.lows = ob.breaker.lows[:n-1]
+	}
+	ob.breaker.lows = append(ob.breaker.lows, point)
+	for n := len(ob.breaker.highs); n > 0 && ob.breaker.highs[n-1].Price.LessThanOrEqual(price); n-- {
+		ob.breaker.highs = ob.breaker.highs[:n-1]
+	}
+	ob.breaker.highs = append(ob.breaker.highs, point)
+}
+
+// window 窗口内的最低和最高成交价，窗口内没有成交时为0
+func (ob *Orderbook) window() (low decimal.Decimal, high decimal.Decimal) {
+	ob.prune()
+	if len(ob.breaker.lows) > 0 {
+		low = ob.breaker.lows[0].Price
+	}
+	if len(ob.breaker.highs) > 0 {
+		high = ob.breaker.highs[0].Price
+	}
+	return low, high
+}
+
+// exceeds 成交价相对最低价上涨或相对最高价下跌是否超过熔断阈值
+func (ob *Orderbook) exceeds(price decimal.Decimal, low decimal.Decimal, high decimal.Decimal) bool {
+	one := decimal.NewFromInt(1)
+	if low.IsPositive() && price.GreaterThan(low.Mul(one.Add(ob.spec.BreakerPercent))) {
+		return true
+	}
+	return high.IsPositive() && price.LessThan(high.Mul(one.Sub(ob.spec.BreakerPercent)))
+}
+
+// breached 成交价是否触发熔断
+func (ob *Orderbook) breached(price decimal.Decimal) bool {
+	if !ob.enabled() {
+		return false
+	}
+	low, high := ob.window()
+	return ob.exceeds(price, low, high)
+}
+
+// trip 触发熔断，进入波动性集合竞价或暂停交易。
+// 集合竞价时GTC/GTD限价单的剩余部分由调用方挂单，其他订单的剩余部分撤销
+func (ob *Orderbook) trip(order *models.Order) []models.Trade {
+	if ob.spec.BreakerDuration > 0 {
+		ob.breaker.until = ob.now + ob.spec.BreakerDuration
+	}
+	if ob.spec.BreakerAuction {
+		ob.setPhase(models.PhaseAuction, models.ReasonBreaker)
+		if order.Type == models.Limit && (order.TimeInForce == models.TimeInForceGTC || order.TimeInForce == models.TimeInForceGTD) {
+			return nil
+		}
+	} else {
+		ob.setPhase(models.PhaseHalted, models.ReasonBreaker)
+	}
+	if order.IsQuote() { // 按金额下单的市价单由matchQuote撤销剩余金额
+		return nil
+	}
+	trade := cancelTrade(order, models.ReasonBreaker)
+	order.Amount = decimal.Zero
+	return []models.Trade{trade}
+}
+
+// handleBreaker 熔断到期时写入恢复指令，暂停交易时恢复连续撮合，集合竞价时按均衡价撮合后恢复
+func (ob *Orderbook) handleBreaker(now int64) {
+	if ob.breaker.until == 0 || now < ob.breaker.until {
+		return
+	}
+	switch ob.phase {
+	case models.PhaseHalted:
+		ob.handle(&journal.Entry{Op: journal.OpResume})
+	case models.PhaseAuction:
+		ob.handle(&journal.Entry{Op: journal.OpUncross})
+	default:
+		ob.breaker.until = 0
+	}
+}
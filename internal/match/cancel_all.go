@@ -0,0 +1,100 @@
+package match
+
+import (
+	"github.com/shopspring/decimal"
+	"lightning-engine/internal/journal"
+	"lightning-engine/models"
+	"lightning-engine/pqueue/skiplist"
+	"lightning-engine/utils"
+	"time"
+)
+
+// CancelAll 同步批量撤单，userId为0时撤销所有用户，side为空时撤销买卖双方，包括止损单。返回撤销的订单数
+func (ob *Orderbook) CancelAll(userId int64, side string) (int, error) {
+	if side != "" && side != models.Buy && side != models.Sell {
+		return 0, ErrOrderSide
+	}
+	ob.status.Add(1)
+	defer ob.status.Done()
+	req := massRequest{userId: userId, side: side, reply: make(chan addResult, 1)}
+	if err := ob.submit(func() error {
+		select {
+		case ob.chMass <- req:
+			return nil
+		case <-time.After(time.Second):
+			return ErrTimeout
+		case <-ob.status.Context().Done():
+			return ErrClosed
+		}
+	}); err != nil {
+		return 0, err
+	}
+	select {
+	case result := <-req.reply:
+		return cancelled(result.trades), result.err
+	case <-time.After(time.Second):
+		return 0, ErrTimeout
+	case <-ob.status.Context().Done():
+		return 0, ErrClosed
+	}
+}
+
+// handleCancelAll 处理批量撤单请求
+func (ob *Orderbook) handleCancelAll(req massRequest) {
+	trades, err := ob.handle(&journal.Entry{Op: journal.OpCancelAll, UserId: req.userId, Side: req.side})
+	req.reply <- addResult{trades: trades, err: err}
+}
+
+// cancelAll 撤销符合条件的订单，每个订单推送一条撤销记录，最后推送一条汇总事件
+func (ob *Orderbook) cancelAll(userId int64, side string) []models.Trade {
+	trades := make([]models.Trade, 0)
+	if side != models.Sell {
+		trades = append(trades, cancelMatched(ob.bid, ob.mBid, userId, models.ReasonCancelAll)...)
+		trades = append(trades, cancelMatched(ob.stopBid, ob.mStopBid, userId, models.ReasonCancelAll)...)
+	}
+	if side != models.Buy {
+		trades = append(trades, cancelMatched(ob.ask, ob.mAsk, userId, models.ReasonCancelAll)...)
+		trades = append(trades, cancelMatched(ob.stopAsk, ob.mStopAsk, userId, models.ReasonCancelAll)...)
+	}
+	trades = append(trades, ob.unlinkTrades(trades)...)
+	if len(trades) > 0 {
+		ob.PushTrades(trades...)
+	}
+	ob.PushEvents(models.Event{
+		Id:     utils.GenTradeId(),
+		Pair:   ob.pair,
+		Type:   models.EventCancelAll,
+		UserId: userId,
+		Side:   side,
+		Count:  int64(cancelled(trades)),
+		Ts:     utils.NowUnixMilli(),
+	})
+	return trades
+}
+
+// cancelled 批量撤单撤销的订单数，不包括OCO关联订单的撤销、挂钩单重新定价和只减仓调整等记录
+func cancelled(trades []models.Trade) int {
+	count := 0
+	for _, trade := range trades {
+		if trade.TakerOrderType == models.Cancel && trade.Reason == models.ReasonCancelAll {
+			count++
+		}
+	}
+	return count
+}
+
+// cancelMatched 遍历跳表，撤销userId的订单，userId为0时撤销全部订单
+func cancelMatched(orders book, index map[string]decimal.Decimal, userId int64, reason string) []models.Trade {
+	nodes := make([]*skiplist.SkipListNode, 0)
+	for p := orders.First(); p != nil; p = p.Next(0) {
+		if userId == 0 || p.Value().GetUserId() == userId {
+			nodes = append(nodes, p)
+		}
+	}
+	trades := make([]models.Trade, 0, len(nodes))
+	for _, node := range nodes {
+		trades = append(trades, cancelTrade(node.Value().(*models.Order), reason))
+		remove(orders, index, node)
+	}
+	return trades
+}
@@ -0,0 +1,108 @@
+package match
+
+import (
+	"container/heap"
+	"github.com/shopspring/decimal"
+	"lightning-engine/internal/journal"
+	"lightning-engine/models"
+	"time"
+)
+
+// expireInterval 检查GTD订单是否到期的间隔
+const expireInterval = 100 * time.Millisecond
+
+// expiryItem 过期堆中的GTD订单
+type expiryItem struct {
+	id         string // 订单id
+	expireTime int64  // 过期时间(毫秒时间戳)
+	seq        int64  // 加入顺序，过期时间相同时先加入的先撤销
+}
+
+// expiryHeap 按过期时间排列的小顶堆，实现heap.Interface
+type expiryHeap struct {
+	items []expiryItem
+	seq   int64
+}
+
+func (h *expiryHeap) Len() int { return len(h.items) }
+
+func (h *expiryHeap) Less(i, j int) bool {
+	if h.items[i].expireTime != h.items[j].expireTime {
+		return h.items[i].expireTime < h.items[j].expireTime
+	}
+	return h.items[i].seq < h.items[j].seq
+}
+
+func (h *expiryHeap) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+
+func (h *expiryHeap) Push(x any) { h.items = append(h.items, x.(expiryItem)) }
+
+func (h *expiryHeap) Pop() any {
+	item := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return item
+}
+
+// schedule GTD订单加入过期堆，订单成交或撤销时不从堆中删除，到期时再跳过
+func (ob *Orderbook) schedule(order *models.Order) {
+	if order.TimeInForce != models.TimeInForceGTD {
+		return
+	}
+	ob.expiry.seq++
+	heap.Push(&ob.expiry, expiryItem{id: order.Id, expireTime: order.ExpireTime, seq: ob.expiry.seq})
+}
+
+// handleExpire 有订单到期时写入过期指令，回放日志时按指令中的时间撤销，保证恢复的盘口一致
+func (ob *Orderbook) handleExpire(now int64) {
+	// 先丢弃已成交或撤销的订单，避免写入无效的指令
+	for ob.expiry.Len() > 0 && ob.locate(ob.expiry.items[0].id) == nil {
+		heap.Pop(&ob.expiry)
+	}
+	if ob.expiry.Len() == 0 || ob.expiry.items[0].expireTime > now {
+		return
+	}
+	ob.handle(&journal.Entry{Op: journal.OpExpire, Time: now})
+}
+
+// expire 撤销now之前到期的订单，包括止损单
+func (ob *Orderbook) expire(now int64) []models.Trade {
+	trades := make([]models.Trade, 0)
+	for ob.expiry.Len() > 0 && ob.expiry.items[0].expireTime <= now {
+		item := heap.Pop(&ob.expiry).(expiryItem)
+		s := ob.locate(item.id)
+		if s == nil { // 已成交或撤销
+			continue
+		}
+		node, _ := s.orders.Find(s.index[item.id], item.id)
+		if node == nil {
+			continue
+		}
+		trades = append(trades, cancelTrade(node.Value().(*models.Order), models.ReasonExpired))
+		remove(s.orders, s.index, node)
+	}
+	trades = append(trades, ob.unlinkTrades(trades)...)
+	if len(trades) > 0 {
+		ob.PushTrades(trades...)
+	}
+	return trades
+}
+
+// side 订单所在的跳表及其索引
+type side struct {
+	orders book
+	index  map[string]decimal.Decimal
+}
+
+// locate 查找订单所在的跳表，订单不在盘口和触发簿中时返回nil
+func (ob *Orderbook) locate(id string) *side {
+	if _, ok := ob.mBid[id]; ok {
+		return &side{ob.bid, ob.mBid}
+	} else if _, ok := ob.mAsk[id]; ok {
+		return &side{ob.ask, ob.mAsk}
+	} else if _, ok := ob.mStopBid[id]; ok {
+		return &side{ob.stopBid, ob.mStopBid}
+	} else if _, ok := ob.mStopAsk[id]; ok {
+		return &side{ob.stopAsk, ob.mStopAsk}
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package match
+
+import (
+	"github.com/shopspring/decimal"
+	"lightning-engine/models"
+	"lightning-engine/pqueue/skiplist"
+)
+
+// split 冰山单挂单前拆分为显示数量和隐藏数量，作为taker时按全部数量撮合
+func split(order *models.Order) {
+	if !order.IsIceberg() || order.Amount.LessThanOrEqual(order.DisplayAmount) {
+		return
+	}
+	order.Hidden = order.Amount.Sub(order.DisplayAmount)
+	order.Amount = order.DisplayAmount
+}
+
+// replenish 冰山单显示数量成交完后，从隐藏数量补充并重新排到该价格队尾，失去时间优先级。
+// 没有隐藏数量时返回false，由调用方删除订单
+func replenish(makers book, node *skiplist.SkipListNode) bool {
+	order := node.Value().(*models.Order)
+	if !order.Hidden.IsPositive() {
+		return false
+	}
+	score := node.Score()
+	amount := decimal.Min(order.DisplayAmount, order.Hidden)
+	makers.Delete(score, order.Id)
+	order.Amount = amount
+	order.Hidden = order.Hidden.Sub(amount)
+	makers.Insert(score, order)
+	return true
+}
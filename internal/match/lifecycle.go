@@ -0,0 +1,149 @@
+package match
+
+import (
+	"lightning-engine/internal/journal"
+	"lightning-engine/models"
+	"lightning-engine/utils"
+	"time"
+)
+
+// Halt 暂停交易，拒绝新订单和改单，保留盘口，撤单不受影响
+func (ob *Orderbook) Halt() error {
+	return ob.admin(adminRequest{op: journal.OpHalt})
+}
+
+// Resume 恢复连续撮合，集合竞价中需通过Uncross结束
+func (ob *Orderbook) Resume() error {
+	return ob.admin(adminRequest{op: journal.OpResume})
+}
+
+// Delist 下架交易对，撤销盘口全部订单，等待撮合协程处理完已接收的指令、生成快照并关闭日志后返回
+func (ob *Orderbook) Delist() error {
+	if err := ob.admin(adminRequest{op: journal.OpDelist}); err != nil {
+		return err
+	}
+	<-ob.done
+	return nil
+}
+
+// StartAuction 开始开盘或重新开盘集合竞价，订单只挂单不撮合
+func (ob *Orderbook) StartAuction() error {
+	return ob.admin(adminRequest{op: journal.OpAuction})
+}
+
+// Uncross 结束集合竞价，按均衡价撮合后进入连续撮合
+func (ob *Orderbook) Uncross() error {
+	return ob.admin(adminRequest{op: journal.OpUncross})
+}
+
+// relist 下架后重新上架，撮合协程启动前写日志恢复连续撮合，重启回放时阶段一致
+func (ob *Orderbook) relist() error {
+	_, err := ob.handle(&journal.Entry{Op: journal.OpRelist})
+	return err
+}
+
+// admin 同步发送交易对管理请求
+func (ob *Orderbook) admin(req adminRequest) error {
+	ob.status.Add(1)
+	defer ob.status.Done()
+	req.reply = make(chan error, 1)
+	if err := ob.submit(func() error {
+		select {
+		case ob.chAdmin <- req:
+			return nil
+		case <-time.After(time.Second):
+			return ErrTimeout
+		case <-ob.status.Context().Done():
+			return ErrClosed
+		}
+	}); err != nil {
+		return err
+	}
+	select {
+	case err := <-req.reply:
+		return err
+	case <-time.After(time.Second):
+		return ErrTimeout
+	case <-ob.status.Context().Done():
+		return ErrClosed
+	}
+}
+
+// handleAdmin 处理交易对管理请求，阶段未变化时不写日志。熔断中人工暂停或开始集合竞价时写日志取消熔断到期恢复；
+// 集合竞价中不能暂停，盘口可能交叉，需先通过Uncross撮合
+func (ob *Orderbook) handleAdmin(req adminRequest) error {
+	op := req.op
+	switch {
+	case ob.phase == models.PhaseDelisted:
+		return ErrDelisted
+	case op == journal.OpPosition:
+		_, err := ob.handle(&journal.Entry{Op: op, UserId: req.userId})
+		return err
+	case op == journal.OpHalt && ob.phase == models.PhaseHalted && ob.breaker.until == 0,
+		op == journal.OpResume && ob.phase == models.PhaseContinuous,
+		op == journal.OpAuction && ob.phase == models.PhaseAuction && ob.breaker.until == 0:
+		return nil
+	case op == journal.OpUncross && ob.phase != models.PhaseAuction,
+		op == journal.OpResume && ob.phase == models.PhaseAuction,
+		op == journal.OpHalt && ob.phase == models.PhaseAuction:
+		return ErrPhase
+	}
+	_, err := ob.handle(&journal.Entry{Op: op})
+	return err
+}
+
+// lifecycle 执行交易对管理指令，下架时返回撤销记录
+func (ob *Orderbook) lifecycle(op string) []models.Trade {
+	switch op {
+	case journal.OpHalt: // 人工暂停优先于熔断，熔断到期后不自动恢复
+		ob.breaker.until = 0
+		ob.setPhase(models.PhaseHalted, "")
+	case journal.OpResume:
+		ob.breaker.until = 0
+		ob.setPhase(models.PhaseContinuous, "")
+	case journal.OpDelist:
+		trades := make([]models.Trade, 0)
+		trades = append(trades, cancelMatched(ob.bid, ob.mBid, 0, models.ReasonDelisted)...)
+		trades = append(trades, cancelMatched(ob.stopBid, ob.mStopBid, 0, models.ReasonDelisted)...)
+		trades = append(trades, cancelMatched(ob.ask, ob.mAsk, 0, models.ReasonDelisted)...)
+		trades = append(trades, cancelMatched(ob.stopAsk, ob.mStopAsk, 0, models.ReasonDelisted)...)
+		trades = append(trades, ob.unlinkTrades(trades)...)
+		if len(trades) > 0 {
+			ob.PushTrades(trades...)
+		}
+		ob.setPhase(models.PhaseDelisted, "")
+		return trades
+	case journal.OpRelist:
+		ob.setPhase(models.PhaseContinuous, "")
+	case journal.OpAuction:
+		ob.breaker.until = 0
+		ob.setPhase(models.PhaseAuction, "")
+	case journal.OpUncross:
+		return ob.uncross()
+	}
+	return nil
+}
+
+// tradable 当前阶段是否接受新订单，不接受时返回撤销原因和错误
+func (ob *Orderbook) tradable() (string, error) {
+	switch ob.phase {
+	case models.PhaseHalted:
+		return models.ReasonHalted, ErrHalted
+	case models.PhaseDelisted:
+		return models.ReasonDelisted, ErrDelisted
+	}
+	return "", nil
+}
+
+// setPhase 切换交易阶段并推送事件，reason为切换原因
+func (ob *Orderbook) setPhase(phase string, reason string) {
+	ob.phase = phase
+	ob.PushEvents(models.Event{
+		Id:     utils.GenTradeId(),
+		Pair:   ob.pair,
+		Type:   models.EventPhase,
+		Phase:  phase,
+		Reason: reason,
+		Ts:     utils.NowUnixMilli(),
+	})
+}
@@ -1,8 +1,12 @@
 package match
 
 import (
+	"errors"
 	"fmt"
 	"github.com/shopspring/decimal"
+	"lightning-engine/internal/conf"
+	"lightning-engine/internal/journal"
+	"lightning-engine/internal/snapshot"
 	"lightning-engine/internal/status"
 	"lightning-engine/models"
 	"lightning-engine/mq"
@@ -13,12 +17,12 @@ import (
 
 var (
 	mp    *MatchPool
-	pairs = []string{"BTC/USDT", "ETH/USDT"}
+	pairs = []conf.Pair{{Symbol: "BTC/USDT"}, {Symbol: "ETH/USDT"}}
 	pair  = "BTC/USDT"
 )
 
 func TestMain(m *testing.M) {
-	mp, _ = NewMatchPool(status.NewStatus(), pairs, &mq.YourMq{})
+	mp, _ = NewMatchPool(status.NewStatus(), pairs, &mq.YourMq{}, nil, nil)
 	m.Run()
 }
 
@@ -305,3 +309,1341 @@ func TestOrderbook_TS_ADD_CANCEL(t *testing.T) {
 	ts2 := utils.NowUnixMilli() - begin
 	t.Logf("撤销%d条数据: %dms", size, ts2)
 }
+
+func TestOrderbook_Depth(t *testing.T) {
+	ob, err := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prices := []int64{100, 100, 99, 98, 101, 101, 102}
+	sides := []string{models.Buy, models.Buy, models.Buy, models.Buy, models.Sell, models.Sell, models.Sell}
+	for i, p := range prices {
+		ob.add(models.Order{
+			Id:          strconv.Itoa(i),
+			UserId:      1,
+			Pair:        pair,
+			Price:       decimal.NewFromInt(p),
+			Amount:      decimal.NewFromInt(2),
+			Side:        sides[i],
+			Type:        models.Limit,
+			TimeInForce: models.TimeInForceGTC,
+		})
+	}
+
+	depth := ob.depth(2)
+	if len(depth.Bids) != 2 || len(depth.Asks) != 2 {
+		t.Fatalf("depth levels error, bids:%d asks:%d", len(depth.Bids), len(depth.Asks))
+	}
+	if !depth.Bids[0].Price.Equal(decimal.NewFromInt(100)) || !depth.Bids[0].Amount.Equal(decimal.NewFromInt(4)) || depth.Bids[0].Count != 2 {
+		t.Errorf("bid level error: %+v", depth.Bids[0])
+	}
+	if !depth.Bids[1].Price.Equal(decimal.NewFromInt(99)) {
+		t.Errorf("bid level error: %+v", depth.Bids[1])
+	}
+	if !depth.Asks[0].Price.Equal(decimal.NewFromInt(101)) || !depth.Asks[0].Amount.Equal(decimal.NewFromInt(4)) || depth.Asks[0].Count != 2 {
+		t.Errorf("ask level error: %+v", depth.Asks[0])
+	}
+
+	depth = ob.depth(0)
+	if len(depth.Bids) != 3 || len(depth.Asks) != 2 {
+		t.Errorf("full depth error, bids:%d asks:%d", len(depth.Bids), len(depth.Asks))
+	}
+}
+
+func TestOrderbook_Recover(t *testing.T) {
+	data := &conf.Data{Dir: t.TempDir()}
+	ob, _ := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+	if err := ob.Recover(data); err != nil {
+		t.Fatal(err)
+	}
+	handleTestOrders(ob)
+	before := ob.depth(0)
+	ob.closeJournal()
+
+	// 模拟崩溃重启，从日志恢复
+	recovered, _ := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+	if err := recovered.Recover(data); err != nil {
+		t.Fatal(err)
+	}
+	defer recovered.closeJournal()
+	after := recovered.depth(0)
+	if fmt.Sprint(before.Bids, before.Asks) != fmt.Sprint(after.Bids, after.Asks) {
+		t.Errorf("recover error\nbefore: %v %v\nafter: %v %v", before.Bids, before.Asks, after.Bids, after.Asks)
+	}
+	if recovered.journal.Seq() != 11 {
+		t.Errorf("journal seq error: %d", recovered.journal.Seq())
+	}
+}
+
+func TestOrderbook_Relist(t *testing.T) {
+	data := &conf.Data{Dir: t.TempDir()}
+	ob, _ := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+	if err := ob.Recover(data); err != nil {
+		t.Fatal(err)
+	}
+	ob.handle(&journal.Entry{Op: journal.OpDelist})
+	ob.closeJournal()
+
+	// 重新上架后撮合
+	relisted, _ := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+	if err := relisted.Recover(data); err != nil || relisted.phase != models.PhaseDelisted {
+		t.Fatalf("recover delisted pair error: %v %s", err, relisted.phase)
+	}
+	if err := relisted.relist(); err != nil {
+		t.Fatal(err)
+	}
+	bid, ask := newTestOrder("b1", models.Buy, models.Limit, 100, 1), newTestOrder("a1", models.Sell, models.Limit, 100, 1)
+	relisted.handle(&journal.Entry{Op: journal.OpAdd, Order: &bid})
+	relisted.handle(&journal.Entry{Op: journal.OpAdd, Order: &ask})
+	relisted.closeJournal()
+
+	// 重启后回放重新上架指令，订单同样成交
+	recovered, _ := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+	if err := recovered.Recover(data); err != nil {
+		t.Fatal(err)
+	}
+	defer recovered.closeJournal()
+	if recovered.phase != models.PhaseContinuous || recovered.bid.First() != nil || recovered.ask.First() != nil {
+		t.Errorf("relist replay error: %s", recovered.phase)
+	}
+}
+
+func TestOrderbook_RecoverSnapshot(t *testing.T) {
+	data := &conf.Data{Dir: t.TempDir(), SnapshotInterval: 4}
+	ob, _ := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+	if err := ob.Recover(data); err != nil {
+		t.Fatal(err)
+	}
+	handleTestOrders(ob)
+	before := ob.depth(0)
+	ob.closeJournal()
+	if ob.snapshotSeq != 8 {
+		t.Fatalf("snapshot seq error: %d", ob.snapshotSeq)
+	}
+
+	recovered, _ := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+	if err := recovered.Recover(data); err != nil {
+		t.Fatal(err)
+	}
+	defer recovered.closeJournal()
+	if recovered.snapshotSeq != 8 {
+		t.Errorf("recover snapshot seq error: %d", recovered.snapshotSeq)
+	}
+	after := recovered.depth(0)
+	if fmt.Sprint(before.Bids, before.Asks) != fmt.Sprint(after.Bids, after.Asks) {
+		t.Errorf("recover error\nbefore: %v %v\nafter: %v %v", before.Bids, before.Asks, after.Bids, after.Asks)
+	}
+	seqs, _ := snapshot.List(data.Dir, pair)
+	if len(seqs) != 2 {
+		t.Errorf("snapshot purge error: %v", seqs)
+	}
+}
+
+// handleTestOrders 写入日志并处理10笔挂单和1笔撤单
+func handleTestOrders(ob *Orderbook) {
+	for i := 0; i < 10; i++ {
+		side := models.Buy
+		if i%2 == 1 {
+			side = models.Sell
+		}
+		order := models.Order{
+			Id:          strconv.Itoa(i),
+			UserId:      1,
+			Pair:        pair,
+			Price:       decimal.NewFromInt(int64(100 + i)),
+			Amount:      decimal.NewFromInt(3),
+			Side:        side,
+			Type:        models.Limit,
+			TimeInForce: models.TimeInForceGTC,
+		}
+		ob.handle(&journal.Entry{Op: journal.OpAdd, Order: &order})
+	}
+	ob.handle(&journal.Entry{Op: journal.OpCancel, Id: "8"})
+}
+
+func TestOrderbook_AddSync(t *testing.T) {
+	st := status.NewStatus()
+	ob, _ := NewOrderbook(st, conf.Pair{Symbol: pair}, &mq.YourMq{})
+	st.Add(1)
+	go ob.Begin()
+	defer st.Stop()
+
+	maker := &models.Order{
+		Id:          "1",
+		UserId:      1,
+		Pair:        pair,
+		Price:       decimal.NewFromInt(100),
+		Amount:      decimal.NewFromInt(1),
+		Side:        models.Sell,
+		Type:        models.Limit,
+		TimeInForce: models.TimeInForceGTC,
+	}
+	trades, err := ob.AddSync(maker)
+	if err != nil || len(trades) != 0 {
+		t.Fatalf("add maker error: %v %+v", err, trades)
+	}
+
+	taker := &models.Order{
+		Id:          "2",
+		UserId:      2,
+		Pair:        pair,
+		Price:       decimal.NewFromInt(100),
+		Amount:      decimal.NewFromInt(3),
+		Side:        models.Buy,
+		Type:        models.Limit,
+		TimeInForce: models.TimeInForceIOC,
+	}
+	trades, err = ob.AddSync(taker)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 2 || trades[0].MakerId != "1" || trades[0].Amount != "1" || trades[1].TakerOrderType != models.Cancel || trades[1].Amount != "2" {
+		t.Errorf("sync trades error: %+v", trades)
+	}
+
+	taker.Id = "3"
+	taker.Side = "long"
+	if _, err := ob.AddSync(taker); err != ErrOrderSide {
+		t.Errorf("want ErrOrderSide, got %v", err)
+	}
+}
+
+// newTestOrder 生成测试订单
+func newTestOrder(id string, side string, typ string, price int64, amount int64) models.Order {
+	return models.Order{
+		Id:          id,
+		UserId:      1,
+		Pair:        pair,
+		Price:       decimal.NewFromInt(price),
+		Amount:      decimal.NewFromInt(amount),
+		Side:        side,
+		Type:        typ,
+		TimeInForce: models.TimeInForceGTC,
+	}
+}
+
+// applyOrder 在当前协程中执行挂单指令，返回产生的成交单
+func applyOrder(ob *Orderbook, order models.Order) []models.Trade {
+	trades, _ := ob.apply(&journal.Entry{Op: journal.OpAdd, Order: &order})
+	return trades
+}
+
+func TestOrderbook_Stop(t *testing.T) {
+	ob, _ := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+	applyOrder(ob, newTestOrder("a1", models.Sell, models.Limit, 100, 1))
+	applyOrder(ob, newTestOrder("a2", models.Sell, models.Limit, 101, 1))
+	applyOrder(ob, newTestOrder("a3", models.Sell, models.Limit, 105, 5))
+
+	// 没有成交价时挂止损单
+	stop := newTestOrder("s1", models.Buy, models.StopMarket, 0, 2)
+	stop.TriggerPrice = decimal.NewFromInt(101)
+	if trades := applyOrder(ob, stop); len(trades) != 0 {
+		t.Fatalf("stop should rest: %+v", trades)
+	}
+	stopLimit := newTestOrder("s2", models.Sell, models.StopLimit, 90, 1)
+	stopLimit.TriggerPrice = decimal.NewFromInt(95)
+	applyOrder(ob, stopLimit)
+
+	// 成交价100，未达到触发价
+	applyOrder(ob, newTestOrder("b1", models.Buy, models.Limit, 100, 1))
+	if _, ok := ob.mStopBid["s1"]; !ok {
+		t.Fatal("stop should not trigger")
+	}
+
+	// 成交价101，触发止损市价单，吃掉105的卖单
+	trades := applyOrder(ob, newTestOrder("b2", models.Buy, models.Limit, 101, 1))
+	if len(trades) != 3 || trades[1].TakerOrderType != models.Trigger || trades[1].TakerId != "s1" ||
+		trades[2].MakerId != "a3" || trades[2].Price != "105" || trades[2].Amount != "2" {
+		t.Errorf("trigger trades error: %+v", trades)
+	}
+	if _, ok := ob.mStopBid["s1"]; ok || !ob.lastPrice.Equal(decimal.NewFromInt(105)) {
+		t.Errorf("stop should be removed, last price %s", ob.lastPrice)
+	}
+
+	// 到达时已满足触发条件的止损单被拒绝
+	reject := newTestOrder("s3", models.Sell, models.StopMarket, 0, 1)
+	reject.TriggerPrice = decimal.NewFromInt(110)
+	trades = applyOrder(ob, reject)
+	if len(trades) != 1 || trades[0].TakerOrderType != models.Cancel || trades[0].Reason != models.ReasonStopRejected {
+		t.Errorf("reject trades error: %+v", trades)
+	}
+
+	// 撤销止损单
+	if err := ob.cancel("s2"); err != nil {
+		t.Error(err)
+	}
+	if len(ob.mStopAsk) != 0 || ob.stopAsk.First() != nil {
+		t.Error("cancel stop error")
+	}
+}
+
+func TestOrderbook_PostOnly(t *testing.T) {
+	ob, _ := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+	applyOrder(ob, newTestOrder("a1", models.Sell, models.Limit, 100, 1))
+	bid := newTestOrder("b1", models.Buy, models.Limit, 99, 1)
+	bid.Price = decimal.RequireFromString("99.5")
+	applyOrder(ob, bid)
+
+	// 不会立即成交，正常挂单
+	po := newTestOrder("p1", models.Buy, models.Limit, 99, 1)
+	po.PostOnly = models.PostOnlyReject
+	if trades := applyOrder(ob, po); len(trades) != 0 {
+		t.Errorf("post only should rest: %+v", trades)
+	} else if ok, _ := Accepted(&po, trades); !ok {
+		t.Errorf("resting post only should be accepted")
+	}
+
+	// 会立即成交，拒绝
+	po = newTestOrder("p2", models.Buy, models.Limit, 100, 1)
+	po.PostOnly = models.PostOnlyReject
+	trades := applyOrder(ob, po)
+	if len(trades) != 1 || trades[0].TakerOrderType != models.Cancel || trades[0].Reason != models.ReasonPostOnly {
+		t.Errorf("post only reject error: %+v", trades)
+	}
+	if ok, reason := Accepted(&po, trades); ok || reason != models.ReasonPostOnly {
+		t.Errorf("rejected post only should not be accepted: %s", reason)
+	}
+
+	// 会立即成交，调整到买一价上方一个tick
+	po = newTestOrder("p3", models.Sell, models.Limit, 90, 1)
+	po.PostOnly = models.PostOnlyReprice
+	if trades := applyOrder(ob, po); len(trades) != 0 {
+		t.Errorf("post only reprice should rest: %+v", trades)
+	}
+	if score, ok := ob.mAsk["p3"]; !ok || !score.Equal(decimal.RequireFromString("99.6")) {
+		t.Errorf("post only reprice error: %s", score)
+	}
+}
+
+func TestOrderbook_Stp(t *testing.T) {
+	cases := []struct {
+		stp    string
+		trades int    // 自成交保护事件数
+		rest   string // 剩余挂单
+	}{
+		{models.StpCancelNewest, 1, "a1"},
+		{models.StpCancelOldest, 1, "b1"},
+		{models.StpCancelBoth, 2, ""},
+		{models.StpDecrementCancel, 2, "a1"},
+	}
+	for _, c := range cases {
+		ob, _ := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+		applyOrder(ob, newTestOrder("a1", models.Sell, models.Limit, 100, 2))
+		applyOrder(ob, newTestOrder("a2", models.Sell, models.Limit, 101, 1))
+		ob.ask.First().Value().(*models.Order).UserId = 2
+
+		bid := newTestOrder("b1", models.Buy, models.Limit, 100, 1)
+		bid.UserId = 2
+		bid.Stp = c.stp
+		trades := applyOrder(ob, bid)
+		if len(trades) != c.trades {
+			t.Errorf("%s trades error: %+v", c.stp, trades)
+		}
+		for _, trade := range trades {
+			if trade.TakerOrderType != models.Stp || trade.Reason != c.stp {
+				t.Errorf("%s event error: %+v", c.stp, trade)
+			}
+		}
+		_, bidOk := ob.mBid["b1"]
+		_, askOk := ob.mAsk["a1"]
+		if bidOk != (c.rest == "b1") || askOk != (c.rest == "a1") {
+			t.Errorf("%s rest error: bid %v ask %v", c.stp, bidOk, askOk)
+		}
+	}
+}
+
+func TestOrderbook_Iceberg(t *testing.T) {
+	ob, _ := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+	iceberg := newTestOrder("a1", models.Sell, models.Limit, 100, 5)
+	iceberg.DisplayAmount = decimal.NewFromInt(2)
+	if err := validate(&iceberg); err != nil {
+		t.Fatal(err)
+	}
+	applyOrder(ob, iceberg)
+	applyOrder(ob, newTestOrder("a2", models.Sell, models.Limit, 100, 1))
+
+	// 深度只显示冰山单的显示数量
+	depth := ob.depth(0)
+	if len(depth.Asks) != 1 || !depth.Asks[0].Amount.Equal(decimal.NewFromInt(3)) {
+		t.Errorf("iceberg depth error: %+v", depth.Asks)
+	}
+
+	// 显示数量成交完后补充，并排到a2后面
+	trades := applyOrder(ob, newTestOrder("b1", models.Buy, models.Limit, 100, 3))
+	if len(trades) != 2 || trades[0].MakerId != "a1" || trades[0].Amount != "2" || trades[1].MakerId != "a2" {
+		t.Errorf("iceberg match error: %+v", trades)
+	}
+	first := ob.ask.First().Value().(*models.Order)
+	if first.Id != "a1" || !first.Amount.Equal(decimal.NewFromInt(2)) || !first.Hidden.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("iceberg replenish error: %+v", first)
+	}
+
+	// FOK按冰山单全部剩余数量判断
+	fok := newTestOrder("b2", models.Buy, models.Limit, 100, 3)
+	fok.TimeInForce = models.TimeInForceFOK
+	if trades := applyOrder(ob, fok); len(trades) != 2 || trades[1].Amount != "1" {
+		t.Errorf("iceberg fok error: %+v", trades)
+	}
+	if _, ok := ob.mAsk["a1"]; ok || ob.ask.First() != nil {
+		t.Errorf("iceberg should be filled")
+	}
+}
+
+func TestOrderbook_Amend(t *testing.T) {
+	ob, _ := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+	applyOrder(ob, newTestOrder("b1", models.Buy, models.Limit, 100, 5))
+	applyOrder(ob, newTestOrder("b2", models.Buy, models.Limit, 100, 1))
+	applyOrder(ob, newTestOrder("a1", models.Sell, models.Limit, 102, 2))
+
+	// 减少数量，保持时间优先级
+	trades, err := ob.amend(models.Order{Id: "b1", Amount: decimal.NewFromInt(3)})
+	if err != nil || len(trades) != 1 || trades[0].TakerOrderType != models.Amend || trades[0].Amount != "3" {
+		t.Errorf("amend amount error: %+v, %v", trades, err)
+	}
+	if first := ob.bid.First().Value(); first.GetId() != "b1" || !first.GetAmount().Equal(decimal.NewFromInt(3)) {
+		t.Errorf("amend should keep priority: %+v", first)
+	}
+
+	// 增加数量，重新排队
+	if _, err := ob.amend(models.Order{Id: "b1", Amount: decimal.NewFromInt(4)}); err != nil {
+		t.Fatal(err)
+	}
+	if first := ob.bid.First().Value(); first.GetId() != "b2" {
+		t.Errorf("amend should lose priority: %+v", first)
+	}
+
+	// 修改价格，可以成交时立即撮合
+	trades, err = ob.amend(models.Order{Id: "b1", Price: decimal.NewFromInt(102)})
+	if err != nil || len(trades) != 2 || trades[1].MakerId != "a1" || trades[1].Amount != "2" {
+		t.Errorf("amend price error: %+v, %v", trades, err)
+	}
+	if score, ok := ob.mBid["b1"]; !ok || !score.Equal(decimal.NewFromInt(102)) {
+		t.Errorf("amend price rest error: %s", score)
+	}
+
+	if _, err := ob.amend(models.Order{Id: "x", Amount: decimal.NewFromInt(1)}); err != ErrOrderId {
+		t.Errorf("amend unknown order error: %v", err)
+	}
+
+	// 修改后的订单按交易对规格重新校验
+	ob.spec.MinNotional = decimal.NewFromInt(100)
+	if _, err := ob.amend(models.Order{Id: "b2", Price: decimal.NewFromInt(50)}); err != ErrMinNotional {
+		t.Errorf("amend notional error: %v", err)
+	}
+	iceberg := newTestOrder("i1", models.Buy, models.Limit, 90, 10)
+	iceberg.DisplayAmount = decimal.NewFromInt(2)
+	applyOrder(ob, iceberg)
+	if _, err := ob.amend(models.Order{Id: "i1", Amount: decimal.NewFromInt(2)}); err != ErrDisplayAmount {
+		t.Errorf("amend display amount error: %v", err)
+	}
+	if score := ob.mBid["b2"]; !score.Equal(decimal.NewFromInt(100)) || ob.bid.First().Value().GetId() != "b1" {
+		t.Errorf("rejected amend should not change the order")
+	}
+}
+
+func TestOrderbook_CancelAll(t *testing.T) {
+	ob, _ := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+	applyOrder(ob, newTestOrder("b1", models.Buy, models.Limit, 99, 1))
+	applyOrder(ob, newTestOrder("a1", models.Sell, models.Limit, 101, 1))
+	stop := newTestOrder("s1", models.Sell, models.StopMarket, 0, 1)
+	stop.TriggerPrice = decimal.NewFromInt(90)
+	applyOrder(ob, stop)
+	other := newTestOrder("a2", models.Sell, models.Limit, 102, 1)
+	other.UserId = 2
+	applyOrder(ob, other)
+
+	// 只撤销用户1的卖单，包括止损单
+	trades, err := ob.apply(&journal.Entry{Op: journal.OpCancelAll, UserId: 1, Side: models.Sell})
+	if err != nil || len(trades) != 2 {
+		t.Errorf("cancel all error: %+v, %v", trades, err)
+	}
+	for _, trade := range trades {
+		if trade.TakerOrderType != models.Cancel || trade.Reason != models.ReasonCancelAll || trade.TakerUser != 1 {
+			t.Errorf("cancel all trade error: %+v", trade)
+		}
+	}
+	if len(ob.mAsk) != 1 || len(ob.mStopAsk) != 0 || len(ob.mBid) != 1 {
+		t.Errorf("cancel all index error: %v %v %v", ob.mAsk, ob.mStopAsk, ob.mBid)
+	}
+
+	// 撤销整个交易对，冰山单撤销数量包括隐藏数量
+	iceberg := newTestOrder("i1", models.Buy, models.Limit, 98, 10)
+	iceberg.DisplayAmount = decimal.NewFromInt(2)
+	applyOrder(ob, iceberg)
+	trades, _ = ob.apply(&journal.Entry{Op: journal.OpCancelAll})
+	if len(trades) != 3 || ob.bid.First() != nil || ob.ask.First() != nil || len(ob.mBid)+len(ob.mAsk) != 0 {
+		t.Errorf("cancel pair error: %+v", trades)
+	}
+	for _, trade := range trades {
+		if trade.MakerId == "i1" && trade.Amount != "10" {
+			t.Errorf("iceberg cancel amount error: %+v", trade)
+		}
+	}
+
+	// 撤单后其他用户的挂钩单重新定价，不计入撤销数量
+	applyOrder(ob, newTestOrder("b3", models.Buy, models.Limit, 99, 1))
+	other = newTestOrder("b4", models.Buy, models.Limit, 98, 1)
+	other.UserId = 2
+	applyOrder(ob, other)
+	pegged := newTestOrder("p1", models.Buy, models.Limit, 0, 1)
+	pegged.UserId, pegged.Peg = 2, models.PegPrimary
+	applyOrder(ob, pegged)
+	trades, _ = ob.apply(&journal.Entry{Op: journal.OpCancelAll, UserId: 1})
+	if len(trades) != 2 || trades[1].TakerOrderType != models.Amend || cancelled(trades) != 1 {
+		t.Errorf("cancel all count error: %+v", trades)
+	}
+}
+
+func TestOrderbook_Expire(t *testing.T) {
+	ob, _ := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+	now := utils.NowUnixMilli()
+	gtd := newTestOrder("b1", models.Buy, models.Limit, 100, 1)
+	gtd.TimeInForce = models.TimeInForceGTD
+	gtd.ExpireTime = now - 1
+	if err := validate(&gtd); err != ErrExpireTime {
+		t.Errorf("expired order should be rejected: %v", err)
+	}
+	gtd.ExpireTime = now + 1000
+	if err := validate(&gtd); err != nil {
+		t.Fatal(err)
+	}
+	applyOrder(ob, gtd)
+	stop := newTestOrder("s1", models.Sell, models.StopLimit, 90, 1)
+	stop.TriggerPrice = decimal.NewFromInt(90)
+	stop.TimeInForce = models.TimeInForceGTD
+	stop.ExpireTime = now + 2000
+	applyOrder(ob, stop)
+	filled := newTestOrder("b2", models.Buy, models.Limit, 99, 1)
+	filled.TimeInForce = models.TimeInForceGTD
+	filled.ExpireTime = now + 500
+	applyOrder(ob, filled)
+	applyOrder(ob, newTestOrder("a1", models.Sell, models.Limit, 99, 2)) // 成交b1和b2
+
+	// 未到期不撤销
+	if trades, _ := ob.apply(&journal.Entry{Op: journal.OpExpire, Time: now + 999}); len(trades) != 0 {
+		t.Errorf("expire too early: %+v", trades)
+	}
+	applyOrder(ob, newTestOrder("b3", models.Buy, models.Limit, 98, 1))
+	trades, _ := ob.apply(&journal.Entry{Op: journal.OpExpire, Time: now + 2000})
+	if len(trades) != 1 || trades[0].TakerId != "s1" || trades[0].Reason != models.ReasonExpired {
+		t.Errorf("expire error: %+v", trades)
+	}
+	if ob.expiry.Len() != 0 || len(ob.mStopAsk) != 0 || len(ob.mBid) != 1 {
+		t.Errorf("expire index error: %d %v %v", ob.expiry.Len(), ob.mStopAsk, ob.mBid)
+	}
+}
+
+func TestOrderbook_QuoteMarket(t *testing.T) {
+	ob, _ := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair, LotSize: decimal.RequireFromString("0.01")}, &mq.YourMq{})
+	applyOrder(ob, newTestOrder("a1", models.Sell, models.Limit, 100, 1))
+	applyOrder(ob, newTestOrder("a2", models.Sell, models.Limit, 300, 1))
+
+	// 100买a1全部，剩余150按300价格可买0.5
+	bid := newTestOrder("b1", models.Buy, models.Market, 0, 0)
+	bid.QuoteAmount = decimal.NewFromInt(250)
+	if err := validate(&bid); err != nil {
+		t.Fatal(err)
+	}
+	trades := applyOrder(ob, bid)
+	if len(trades) != 2 || trades[0].Amount != "1" || trades[1].Amount != "0.5" {
+		t.Errorf("quote market error: %+v", trades)
+	}
+
+	// 按LotSize向下取整，未花完的金额撤销
+	bid = newTestOrder("b2", models.Buy, models.Market, 0, 0)
+	bid.QuoteAmount = decimal.NewFromInt(10)
+	trades = applyOrder(ob, bid)
+	if len(trades) != 2 || trades[0].Amount != "0.03" || trades[1].Reason != models.ReasonQuoteUnspent || trades[1].Amount != "1" {
+		t.Errorf("quote market lot error: %+v", trades)
+	}
+
+	// 自成交保护减少数量时扣除对应金额，剩余金额继续与其他用户的订单撮合
+	ob, _ = NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair, LotSize: decimal.NewFromInt(1)}, &mq.YourMq{})
+	applyOrder(ob, newTestOrder("a1", models.Sell, models.Limit, 100, 1))
+	for _, o := range []models.Order{newTestOrder("a2", models.Sell, models.Limit, 100, 1), newTestOrder("a3", models.Sell, models.Limit, 110, 5)} {
+		o.UserId = 2
+		applyOrder(ob, o)
+	}
+	bid = newTestOrder("b3", models.Buy, models.Market, 0, 0)
+	bid.QuoteAmount = decimal.NewFromInt(320)
+	bid.Stp = models.StpDecrementCancel
+	trades = applyOrder(ob, bid)
+	if len(trades) != 5 || trades[0].TakerOrderType != models.Stp || trades[2].MakerId != "a2" || trades[3].MakerId != "a3" ||
+		trades[4].Reason != models.ReasonQuoteUnspent || trades[4].Amount != "10" {
+		t.Errorf("quote market decrement error: %+v", trades)
+	}
+}
+
+func TestOrderbook_Slippage(t *testing.T) {
+	ob, _ := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair, PriceBand: decimal.RequireFromString("0.05")}, &mq.YourMq{})
+	applyOrder(ob, newTestOrder("a1", models.Sell, models.Limit, 100, 1))
+	applyOrder(ob, newTestOrder("a2", models.Sell, models.Limit, 105, 1))
+	applyOrder(ob, newTestOrder("a3", models.Sell, models.Limit, 106, 1))
+	applyOrder(ob, newTestOrder("b1", models.Buy, models.Limit, 90, 1))
+	applyOrder(ob, newTestOrder("b2", models.Buy, models.Limit, 80, 1))
+
+	// 超出100*(1+5%)的部分撤销
+	trades := applyOrder(ob, newTestOrder("m1", models.Buy, models.Market, 0, 3))
+	if len(trades) != 3 || trades[1].MakerId != "a2" || trades[2].TakerOrderType != models.Cancel || trades[2].Reason != models.ReasonSlippage {
+		t.Errorf("price band error: %+v", trades)
+	}
+
+	// 订单最差成交价比价格保护范围更严格
+	ask := newTestOrder("m2", models.Sell, models.Market, 0, 2)
+	ask.ProtectPrice = decimal.NewFromInt(90)
+	trades = applyOrder(ob, ask)
+	if len(trades) != 2 || trades[0].MakerId != "b1" || trades[1].Reason != models.ReasonSlippage || trades[1].Amount != "1" {
+		t.Errorf("protect price error: %+v", trades)
+	}
+
+	// 对手盘耗尽时不是价格保护撤销
+	trades = applyOrder(ob, newTestOrder("m3", models.Buy, models.Market, 0, 2))
+	if len(trades) != 2 || trades[1].Reason != "" {
+		t.Errorf("market cancel reason error: %+v", trades)
+	}
+
+	// 只剩不能成交的全部成交订单时不是价格保护撤销
+	aon := newTestOrder("a4", models.Sell, models.Limit, 100, 5)
+	aon.UserId, aon.AllOrNone = 2, true
+	applyOrder(ob, aon)
+	trades = applyOrder(ob, newTestOrder("m4", models.Buy, models.Market, 0, 1))
+	if len(trades) != 1 || trades[0].TakerOrderType != models.Cancel || trades[0].Reason != "" {
+		t.Errorf("market cancel with aon maker error: %+v", trades)
+	}
+}
+
+func TestOrderbook_Check(t *testing.T) {
+	spec := conf.Pair{
+		Symbol:      pair,
+		LotSize:     decimal.RequireFromString("0.01"),
+		PriceTick:   decimal.RequireFromString("0.5"),
+		PriceScale:  1,
+		AmountScale: 2,
+		MinAmount:   decimal.RequireFromString("0.1"),
+		MaxAmount:   decimal.NewFromInt(100),
+		MinNotional: decimal.NewFromInt(10),
+		MaxNotional: decimal.NewFromInt(10000),
+	}
+	if err := checkPair(spec); err != nil {
+		t.Fatal(err)
+	}
+	ob, _ := NewOrderbook(status.NewStatus(), spec, &mq.YourMq{})
+	cases := []struct {
+		price  string
+		amount string
+		err    error
+	}{
+		{"100.5", "1", nil},
+		{"100.3", "1", ErrPriceTick},
+		{"100.55", "1", ErrPriceScale},
+		{"100", "1.005", ErrAmountScale},
+		{"100", "0.05", ErrMinAmount},
+		{"100", "101", ErrMaxAmount},
+		{"50", "0.1", ErrMinNotional},
+		{"500", "50", ErrMaxNotional},
+	}
+	for _, c := range cases {
+		order := newTestOrder("b1", models.Buy, models.Limit, 0, 0)
+		order.Price = decimal.RequireFromString(c.price)
+		order.Amount = decimal.RequireFromString(c.amount)
+		if err := ob.check(&order); err != c.err {
+			t.Errorf("check %s %s error: %v, want %v", c.price, c.amount, err, c.err)
+		}
+	}
+
+	spec.LotSize = decimal.RequireFromString("0.3")
+	ob.spec = spec
+	order := newTestOrder("b1", models.Buy, models.Limit, 100, 1)
+	if err := ob.check(&order); err != ErrAmountStep {
+		t.Errorf("lot size error: %v", err)
+	}
+	if err := checkPair(conf.Pair{Symbol: pair, MinAmount: decimal.NewFromInt(2), MaxAmount: decimal.NewFromInt(1)}); !errors.Is(err, ErrPairSpec) {
+		t.Errorf("pair spec error: %v", err)
+	}
+}
+
+func TestOrderbook_Lifecycle(t *testing.T) {
+	ob, _ := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+	applyOrder(ob, newTestOrder("b1", models.Buy, models.Limit, 100, 1))
+
+	// 暂停后拒绝挂单，保留盘口
+	ob.apply(&journal.Entry{Op: journal.OpHalt})
+	req := addRequest{order: newTestOrder("a1", models.Sell, models.Limit, 100, 1), reply: make(chan addResult, 1)}
+	ob.handleAdd(req)
+	result := <-req.reply
+	if result.err != ErrHalted || len(result.trades) != 1 || result.trades[0].Reason != models.ReasonHalted {
+		t.Errorf("halt error: %+v", result)
+	}
+	if _, ok := ob.mBid["b1"]; !ok {
+		t.Errorf("halt should keep book")
+	}
+
+	// 恢复后正常撮合
+	ob.apply(&journal.Entry{Op: journal.OpResume})
+	ob.handleAdd(req)
+	if result = <-req.reply; result.err != nil || len(result.trades) != 1 || !result.trades[0].IsFill() {
+		t.Errorf("resume error: %+v", result)
+	}
+
+	// 下架撤销全部订单
+	applyOrder(ob, newTestOrder("b2", models.Buy, models.Limit, 99, 1))
+	trades, _ := ob.apply(&journal.Entry{Op: journal.OpDelist})
+	if len(trades) != 1 || trades[0].Reason != models.ReasonDelisted || ob.phase != models.PhaseDelisted || len(ob.mBid) != 0 {
+		t.Errorf("delist error: %+v", trades)
+	}
+}
+
+func TestMatchPool_Pair(t *testing.T) {
+	pool, _ := NewMatchPool(status.NewStatus(), nil, &mq.YourMq{}, nil, nil)
+	if err := pool.AddPair(conf.Pair{Symbol: "DOGE/USDT"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := pool.AddPair(conf.Pair{Symbol: "DOGE/USDT"}); err != ErrPairExists {
+		t.Errorf("add pair twice error: %v", err)
+	}
+	if err := pool.HaltPair("DOGE/USDT"); err != nil {
+		t.Fatal(err)
+	}
+	order := newTestOrder("b1", models.Buy, models.Limit, 1, 1)
+	order.Pair = "DOGE/USDT"
+	if _, err := pool.AddOrderSync(&order); err != ErrHalted {
+		t.Errorf("halted pair should reject order: %v", err)
+	}
+	if err := pool.ResumePair("DOGE/USDT"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pool.AddOrderSync(&order); err != nil {
+		t.Errorf("resumed pair should accept order: %v", err)
+	}
+	if err := pool.DelistPair("DOGE/USDT"); err != nil {
+		t.Fatal(err)
+	}
+	if err := pool.AddOrder(&order); err != ErrPair {
+		t.Errorf("delisted pair should not exist: %v", err)
+	}
+}
+
+func TestMatchPool_Relist(t *testing.T) {
+	s := status.NewStatus()
+	pool, _ := NewMatchPool(s, nil, &mq.YourMq{}, nil, &conf.Data{Dir: t.TempDir()})
+	if err := pool.AddPair(conf.Pair{Symbol: pair}); err != nil {
+		t.Fatal(err)
+	}
+	ob, _ := pool.get(pair)
+	order := newTestOrder("b1", models.Buy, models.Limit, 100, 1)
+	if _, err := pool.AddOrderSync(&order); err != nil {
+		t.Fatal(err)
+	}
+
+	// 下架返回时撮合协程已退出，日志已关闭，可以立即重新上架
+	if err := pool.DelistPair(pair); err != nil {
+		t.Fatal(err)
+	}
+	if err := ob.Add(&order); err != ErrDelisted {
+		t.Errorf("delisted orderbook should reject order: %v", err)
+	}
+	if err := pool.AddPair(conf.Pair{Symbol: pair}); err != nil {
+		t.Fatal(err)
+	}
+	order.Id = "b2"
+	if _, err := pool.AddOrderSync(&order); err != nil {
+		t.Errorf("relisted pair should accept order: %v", err)
+	}
+	s.Stop()
+	s.Wait()
+}
+
+func TestOrderbook_Auction(t *testing.T) {
+	ob, _ := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+	ob.apply(&journal.Entry{Op: journal.OpAuction})
+	for _, o := range []models.Order{
+		newTestOrder("b1", models.Buy, models.Limit, 102, 1),
+		newTestOrder("b2", models.Buy, models.Limit, 101, 2),
+		newTestOrder("b3", models.Buy, models.Limit, 100, 3),
+		newTestOrder("a1", models.Sell, models.Limit, 99, 2),
+		newTestOrder("a2", models.Sell, models.Limit, 100, 2),
+		newTestOrder("a3", models.Sell, models.Limit, 101, 3),
+	} {
+		if trades := applyOrder(ob, o); len(trades) != 0 {
+			t.Errorf("auction should not match: %+v", trades)
+		}
+	}
+
+	// 集合竞价阶段拒绝市价单
+	trades := applyOrder(ob, newTestOrder("m1", models.Buy, models.Market, 0, 1))
+	if len(trades) != 1 || trades[0].Reason != models.ReasonAuction {
+		t.Errorf("auction market order error: %+v", trades)
+	}
+
+	price, volume := ob.equilibrium()
+	if !price.Equal(decimal.NewFromInt(100)) || !volume.Equal(decimal.NewFromInt(4)) {
+		t.Errorf("equilibrium error: %s %s", price, volume)
+	}
+	trades, _ = ob.apply(&journal.Entry{Op: journal.OpUncross})
+	if len(trades) != 4 || ob.phase != models.PhaseContinuous || !ob.lastPrice.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("uncross error: %+v", trades)
+	}
+	for _, trade := range trades {
+		if trade.Price != "100" {
+			t.Errorf("uncross price error: %+v", trade)
+		}
+	}
+	if ob.bid.First().Score().GreaterThanOrEqual(ob.ask.First().Score()) {
+		t.Errorf("book should not be crossed after uncross")
+	}
+
+	// 集合竞价中盘口交叉，不能暂停后直接恢复连续撮合
+	ob, _ = NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+	ob.handleAdmin(adminRequest{op: journal.OpAuction})
+	applyOrder(ob, newTestOrder("b1", models.Buy, models.Limit, 110, 1))
+	applyOrder(ob, newTestOrder("a1", models.Sell, models.Limit, 100, 1))
+	if err := ob.handleAdmin(adminRequest{op: journal.OpHalt}); err != ErrPhase || ob.phase != models.PhaseAuction {
+		t.Errorf("halt during auction should be rejected: %v %s", err, ob.phase)
+	}
+	if err := ob.handleAdmin(adminRequest{op: journal.OpResume}); err != ErrPhase || ob.phase != models.PhaseAuction {
+		t.Errorf("resume during auction should be rejected: %v %s", err, ob.phase)
+	}
+
+	// 集合竞价撮合同样执行自成交保护
+	ob, _ = NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+	ob.apply(&journal.Entry{Op: journal.OpAuction})
+	self := newTestOrder("b1", models.Buy, models.Limit, 100, 2)
+	self.Stp = models.StpCancelBoth
+	applyOrder(ob, self)
+	other := newTestOrder("b2", models.Buy, models.Limit, 100, 1)
+	other.UserId = 2
+	applyOrder(ob, other)
+	self = newTestOrder("a1", models.Sell, models.Limit, 100, 1)
+	self.Stp = models.StpCancelBoth
+	applyOrder(ob, self)
+	applyOrder(ob, newTestOrder("a2", models.Sell, models.Limit, 100, 1))
+	trades, _ = ob.apply(&journal.Entry{Op: journal.OpUncross})
+	if len(trades) != 3 || trades[0].TakerOrderType != models.Stp || trades[1].TakerOrderType != models.Stp ||
+		trades[2].TakerId != "b2" || trades[2].MakerId != "a2" {
+		t.Errorf("uncross self trade error: %+v", trades)
+	}
+	if ob.bid.First() != nil || ob.ask.First() != nil {
+		t.Errorf("uncross self trade should cancel both orders")
+	}
+
+	// 成交量和未成交量相同时，取最接近最新成交价的价格
+	ob, _ = NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+	ob.apply(&journal.Entry{Op: journal.OpAuction})
+	applyOrder(ob, newTestOrder("b1", models.Buy, models.Limit, 100, 1))
+	applyOrder(ob, newTestOrder("a1", models.Sell, models.Limit, 98, 1))
+	if price, _ := ob.equilibrium(); !price.Equal(decimal.NewFromInt(98)) {
+		t.Errorf("equilibrium without reference error: %s", price)
+	}
+	ob.lastPrice = decimal.NewFromInt(101)
+	if price, _ := ob.equilibrium(); !price.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("equilibrium reference error: %s", price)
+	}
+}
+
+func TestOrderbook_Breaker(t *testing.T) {
+	spec := conf.Pair{Symbol: pair, BreakerPercent: decimal.RequireFromString("0.05"), BreakerWindow: 60000, BreakerDuration: 1000}
+	ob, _ := NewOrderbook(status.NewStatus(), spec, &mq.YourMq{})
+	applyOrder(ob, newTestOrder("a1", models.Sell, models.Limit, 100, 1))
+	applyOrder(ob, newTestOrder("a2", models.Sell, models.Limit, 103, 1))
+	applyOrder(ob, newTestOrder("a3", models.Sell, models.Limit, 106, 1))
+
+	// 106相对窗口内最低价100上涨超过5%，熔断后撤销剩余部分
+	trades := applyOrder(ob, newTestOrder("m1", models.Buy, models.Market, 0, 3))
+	if len(trades) != 3 || trades[2].TakerOrderType != models.Cancel || trades[2].Reason != models.ReasonBreaker || trades[2].Amount != "1" {
+		t.Errorf("breaker trades error: %+v", trades)
+	}
+	if ob.phase != models.PhaseHalted || ob.breaker.until != 1000 {
+		t.Errorf("breaker should halt: %s %d", ob.phase, ob.breaker.until)
+	}
+
+	// 到期后自动恢复
+	ob.handleBreaker(999)
+	if ob.phase != models.PhaseHalted {
+		t.Errorf("breaker should not resume before until")
+	}
+	ob.handleBreaker(1000)
+	if ob.phase != models.PhaseContinuous || ob.breaker.until != 0 {
+		t.Errorf("breaker should resume: %s", ob.phase)
+	}
+
+	// 熔断中人工暂停，到期后不自动恢复
+	applyOrder(ob, newTestOrder("a4", models.Sell, models.Limit, 120, 1))
+	applyOrder(ob, newTestOrder("m2", models.Buy, models.Market, 0, 1))
+	if ob.phase != models.PhaseHalted || ob.breaker.until == 0 {
+		t.Fatalf("breaker should halt again: %s %d", ob.phase, ob.breaker.until)
+	}
+	ob.handleAdmin(adminRequest{op: journal.OpHalt})
+	ob.handleBreaker(ob.now + 1000)
+	if ob.phase != models.PhaseHalted || ob.breaker.until != 0 {
+		t.Errorf("operator halt should not be resumed by breaker: %s", ob.phase)
+	}
+
+	// 波动性集合竞价模式下，GTC限价单剩余部分挂单
+	spec.BreakerAuction = true
+	ob, _ = NewOrderbook(status.NewStatus(), spec, &mq.YourMq{})
+	applyOrder(ob, newTestOrder("a1", models.Sell, models.Limit, 100, 1))
+	applyOrder(ob, newTestOrder("a2", models.Sell, models.Limit, 106, 1))
+
+	// FOK订单不能穿过熔断价格
+	fok := newTestOrder("f1", models.Buy, models.Limit, 106, 2)
+	fok.TimeInForce = models.TimeInForceFOK
+	if trades := applyOrder(ob, fok); len(trades) != 1 || trades[0].TakerOrderType != models.Cancel {
+		t.Errorf("fok should not fill through breaker: %+v", trades)
+	}
+	trades = applyOrder(ob, newTestOrder("b1", models.Buy, models.Limit, 106, 2))
+	if len(trades) != 1 || ob.phase != models.PhaseAuction || ob.mBid["b1"].IsZero() {
+		t.Errorf("breaker auction error: %+v %s", trades, ob.phase)
+	}
+	ob.handleBreaker(1000)
+	if ob.phase != models.PhaseContinuous || !ob.lastPrice.Equal(decimal.NewFromInt(106)) {
+		t.Errorf("breaker auction should uncross: %s %s", ob.phase, ob.lastPrice)
+	}
+}
+
+func TestOrderbook_Oco(t *testing.T) {
+	ob, _ := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+	applyOrder(ob, newTestOrder("a0", models.Sell, models.Limit, 100, 1))
+	applyOrder(ob, newTestOrder("b0", models.Buy, models.Limit, 100, 1))
+	newOco := func(id string, price int64, trigger int64) (models.Order, models.Order) {
+		limit := newTestOrder(id+"-tp", models.Sell, models.Limit, price, 2)
+		stop := newTestOrder(id+"-sl", models.Sell, models.StopMarket, 0, 2)
+		stop.TriggerPrice = decimal.NewFromInt(trigger)
+		return limit, stop
+	}
+	applyOco := func(limit models.Order, stop models.Order) []models.Trade {
+		if err := checkOco(&limit, &stop); err != nil {
+			t.Fatalf("check oco error: %v", err)
+		}
+		trades, _ := ob.apply(&journal.Entry{Op: journal.OpOco, Order: &limit, Link: &stop})
+		return trades
+	}
+
+	// 止盈单部分成交，撤销止损单，止盈单剩余部分继续挂单
+	if trades := applyOco(newOco("o1", 110, 90)); len(trades) != 0 {
+		t.Fatalf("oco should rest: %+v", trades)
+	}
+	trades := applyOrder(ob, newTestOrder("b1", models.Buy, models.Limit, 110, 1))
+	if len(trades) != 2 || trades[1].TakerId != "o1-sl" || trades[1].Reason != models.ReasonOco {
+		t.Errorf("oco fill should cancel stop: %+v", trades)
+	}
+	if _, ok := ob.mStopAsk["o1-sl"]; ok || ob.mAsk["o1-tp"].IsZero() || len(ob.links) != 0 {
+		t.Errorf("oco stop should be removed")
+	}
+
+	// 止损单触发，撤销止盈单
+	applyOco(newOco("o2", 120, 95))
+	applyOrder(ob, newTestOrder("b2", models.Buy, models.Limit, 94, 2))
+	applyOrder(ob, newTestOrder("a2", models.Sell, models.Limit, 95, 1))
+	trades = applyOrder(ob, newTestOrder("b3", models.Buy, models.Limit, 95, 1))
+	if len(trades) != 4 || trades[1].TakerOrderType != models.Trigger || trades[2].TakerId != "o2-tp" ||
+		trades[2].Reason != models.ReasonOco || trades[3].TakerId != "o2-sl" {
+		t.Errorf("oco trigger should cancel limit: %+v", trades)
+	}
+
+	// 撤销一方，另一方一起撤销
+	applyOco(newOco("o3", 130, 80))
+	trades, _ = ob.apply(&journal.Entry{Op: journal.OpCancel, Id: "o3-tp"})
+	if len(trades) != 1 || trades[0].TakerId != "o3-sl" || ob.locate("o3-sl") != nil {
+		t.Errorf("oco cancel should cancel sibling: %+v", trades)
+	}
+
+	// 止损单已满足触发条件被拒绝，止盈单不挂单
+	trades = applyOco(newOco("o4", 130, 96))
+	if len(trades) != 2 || trades[0].Reason != models.ReasonStopRejected || trades[1].TakerId != "o4-tp" || ob.locate("o4-tp") != nil {
+		t.Errorf("oco rejected stop should cancel limit: %+v", trades)
+	}
+
+	limit, stop := newOco("o5", 130, 80)
+	stop.Side = models.Buy
+	if err := checkOco(&limit, &stop); !errors.Is(err, ErrOco) {
+		t.Errorf("oco side should be checked: %v", err)
+	}
+}
+
+func TestOrderbook_Trailing(t *testing.T) {
+	ob, _ := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+
+	// 没有最新成交价时拒绝
+	stop := newTestOrder("s1", models.Sell, models.StopMarket, 0, 1)
+	stop.TrailingAmount = decimal.NewFromInt(5)
+	if err := validate(&stop); err != nil {
+		t.Fatalf("trailing stop should be valid: %v", err)
+	}
+	if trades := applyOrder(ob, stop); len(trades) != 1 || trades[0].Reason != models.ReasonStopRejected {
+		t.Errorf("trailing stop without last price should be rejected: %+v", trades)
+	}
+
+	applyOrder(ob, newTestOrder("a1", models.Sell, models.Limit, 100, 1))
+	applyOrder(ob, newTestOrder("b1", models.Buy, models.Limit, 100, 1))
+	trades := applyOrder(ob, stop)
+	if len(trades) != 1 || trades[0].TakerOrderType != models.Trail || trades[0].Price != "95" {
+		t.Errorf("trailing stop initial trigger error: %+v", trades)
+	}
+	buy := newTestOrder("s2", models.Buy, models.StopMarket, 0, 1)
+	buy.TrailingPercent = decimal.RequireFromString("0.2")
+	applyOrder(ob, buy)
+
+	// 成交价上涨到110，卖单触发价跟随到105，买单不变
+	applyOrder(ob, newTestOrder("a2", models.Sell, models.Limit, 110, 1))
+	trades = applyOrder(ob, newTestOrder("b2", models.Buy, models.Limit, 110, 1))
+	if len(trades) != 2 || trades[1].TakerId != "s1" || trades[1].Price != "105" || !ob.mStopAsk["s1"].Equal(decimal.NewFromInt(105)) {
+		t.Errorf("trailing stop should follow high: %+v", trades)
+	}
+	if !ob.mStopBid["s2"].Equal(decimal.NewFromInt(120)) {
+		t.Errorf("buy trailing stop should not move: %s", ob.mStopBid["s2"])
+	}
+
+	// 成交价回落到104，卖单触发后以90成交，买单触发价跟随到108
+	applyOrder(ob, newTestOrder("b3", models.Buy, models.Limit, 90, 1))
+	applyOrder(ob, newTestOrder("a3", models.Sell, models.Limit, 104, 1))
+	trades = applyOrder(ob, newTestOrder("b4", models.Buy, models.Limit, 104, 1))
+	if len(trades) != 4 || trades[1].TakerOrderType != models.Trigger || trades[1].TakerId != "s1" ||
+		trades[2].MakerId != "b3" || trades[3].TakerId != "s2" || trades[3].Price != "108" {
+		t.Errorf("trailing stop should trigger: %+v", trades)
+	}
+	ob.trail(trades)
+	if _, ok := ob.trails["s1"]; ok {
+		t.Errorf("triggered trailing stop should be removed")
+	}
+
+	invalid := newTestOrder("s3", models.Sell, models.StopMarket, 0, 1)
+	invalid.TrailingAmount = decimal.NewFromInt(5)
+	invalid.TrailingPercent = decimal.RequireFromString("0.1")
+	if err := validate(&invalid); !errors.Is(err, ErrTrailing) {
+		t.Errorf("trailing amount and percent should be exclusive: %v", err)
+	}
+}
+
+func TestOrderbook_TrailingSnapshot(t *testing.T) {
+	data := &conf.Data{Dir: t.TempDir()}
+	ob, _ := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+	if err := ob.Recover(data); err != nil {
+		t.Fatal(err)
+	}
+	ask, bid := newTestOrder("a1", models.Sell, models.Limit, 100, 1), newTestOrder("b1", models.Buy, models.Limit, 100, 1)
+	ob.handle(&journal.Entry{Op: journal.OpAdd, Order: &ask})
+	ob.handle(&journal.Entry{Op: journal.OpAdd, Order: &bid})
+
+	// 价差不小于最新成交价时触发价不为正数，拒绝
+	invalid := newTestOrder("s1", models.Sell, models.StopMarket, 0, 1)
+	invalid.TrailingAmount = decimal.NewFromInt(150)
+	if trades, _ := ob.handle(&journal.Entry{Op: journal.OpAdd, Order: &invalid}); len(trades) != 1 || trades[0].Reason != models.ReasonStopRejected {
+		t.Errorf("trailing stop with non-positive trigger should be rejected: %+v", trades)
+	}
+	stop := newTestOrder("s2", models.Sell, models.StopMarket, 0, 1)
+	stop.TrailingAmount = decimal.NewFromInt(5)
+	ob.handle(&journal.Entry{Op: journal.OpAdd, Order: &stop})
+	if err := ob.snapshot(); err != nil {
+		t.Fatal(err)
+	}
+	ob.closeJournal()
+
+	// 从快照恢复跟踪止损单
+	if snap, err := snapshot.Latest(data.Dir, pair); err != nil || snap == nil {
+		t.Fatalf("latest snapshot error: %v", err)
+	}
+	recovered, _ := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+	if err := recovered.Recover(data); err != nil {
+		t.Fatal(err)
+	}
+	defer recovered.closeJournal()
+	if _, ok := recovered.trails["s2"]; !ok || !recovered.mStopAsk["s2"].Equal(decimal.NewFromInt(95)) || len(recovered.mStopAsk) != 1 {
+		t.Errorf("trailing stop snapshot error: %v", recovered.mStopAsk)
+	}
+}
+
+func TestOrderbook_Peg(t *testing.T) {
+	ob, _ := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+	newPegged := func(id string, side string, peg string, amount int64) models.Order {
+		order := newTestOrder(id, side, models.Limit, 0, amount)
+		order.Peg = peg
+		return order
+	}
+
+	// 没有参考价时拒绝
+	if trades := applyOrder(ob, newPegged("p0", models.Buy, models.PegPrimary, 1)); len(trades) != 1 || trades[0].Reason != models.ReasonPeg {
+		t.Errorf("peg without reference should be rejected: %+v", trades)
+	}
+
+	applyOrder(ob, newTestOrder("b1", models.Buy, models.Limit, 99, 1))
+	applyOrder(ob, newTestOrder("a1", models.Sell, models.Limit, 101, 1))
+	applyOrder(ob, newPegged("p1", models.Buy, models.PegPrimary, 1))
+	applyOrder(ob, newPegged("p2", models.Sell, models.PegMidpoint, 1))
+	applyOrder(ob, newPegged("p3", models.Buy, models.PegMidpoint, 1))
+	capped := newPegged("p4", models.Buy, models.PegPrimary, 1)
+	capped.PegLimit = decimal.NewFromInt(95)
+	applyOrder(ob, capped)
+	// 中间价100，卖单挂100，买单会与卖单成交，调整到99
+	for id, price := range map[string]int64{"p1": 99, "p2": 100, "p3": 99, "p4": 95} {
+		score, ok := ob.mBid[id]
+		if !ok {
+			score = ob.mAsk[id]
+		}
+		if !score.Equal(decimal.NewFromInt(price)) {
+			t.Errorf("peg %s price error: %s", id, score)
+		}
+	}
+
+	// 买一价变为100，挂钩单重新定价，同价位的普通订单优先
+	trades := applyOrder(ob, newTestOrder("b2", models.Buy, models.Limit, 100, 2))
+	if len(trades) != 3 || trades[0].MakerId != "p2" || trades[1].TakerId != "p1" || trades[1].Price != "100" ||
+		trades[1].TakerOrderType != models.Amend || trades[2].TakerId != "p3" || trades[2].Price != "100" {
+		t.Errorf("peg reprice error: %+v", trades)
+	}
+	if ob.bid.First().Value().GetId() != "b2" || !ob.mBid["p4"].Equal(decimal.NewFromInt(95)) {
+		t.Errorf("repriced peg should lose priority")
+	}
+	if _, ok := ob.pegs["p2"]; ok {
+		t.Errorf("filled peg should be removed")
+	}
+
+	if _, err := ob.apply(&journal.Entry{Op: journal.OpAmend, Order: &models.Order{Id: "p1", Price: decimal.NewFromInt(98)}}); !errors.Is(err, ErrPeg) {
+		t.Errorf("peg price should not be amended: %v", err)
+	}
+}
+
+func TestOrderbook_Invisible(t *testing.T) {
+	ob, _ := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+	hidden := newTestOrder("a1", models.Sell, models.Limit, 100, 1)
+	hidden.Invisible = true
+	applyOrder(ob, hidden)
+	applyOrder(ob, newTestOrder("a2", models.Sell, models.Limit, 100, 1))
+	applyOrder(ob, newTestOrder("a3", models.Sell, models.Limit, 101, 1))
+
+	// 隐藏单不计入深度
+	depth := ob.depth(0)
+	if len(depth.Asks) != 2 || !depth.Asks[0].Amount.Equal(decimal.NewFromInt(1)) || depth.Asks[0].Count != 1 {
+		t.Errorf("hidden order should not be in depth: %+v", depth.Asks)
+	}
+
+	// 同一价格先与显示的订单成交
+	trades := applyOrder(ob, newTestOrder("b1", models.Buy, models.Limit, 100, 1))
+	if len(trades) != 1 || trades[0].MakerId != "a2" {
+		t.Errorf("displayed order should match first: %+v", trades)
+	}
+	trades = applyOrder(ob, newTestOrder("b2", models.Buy, models.Limit, 100, 1))
+	if len(trades) != 1 || trades[0].MakerId != "a1" {
+		t.Errorf("hidden order should match: %+v", trades)
+	}
+
+	invalid := newTestOrder("a4", models.Sell, models.Market, 0, 1)
+	invalid.Invisible = true
+	if err := validate(&invalid); !errors.Is(err, ErrInvisible) {
+		t.Errorf("hidden market order should be rejected: %v", err)
+	}
+}
+
+func TestOrderbook_MinQty(t *testing.T) {
+	ob, _ := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+	aon := newTestOrder("a1", models.Sell, models.Limit, 100, 5)
+	aon.AllOrNone = true
+	applyOrder(ob, aon)
+	applyOrder(ob, newTestOrder("a2", models.Sell, models.Limit, 100, 1))
+	applyOrder(ob, newTestOrder("a3", models.Sell, models.Limit, 101, 2))
+
+	// 跳过不能全部成交的全部成交单，后面的订单按价格时间优先成交
+	trades := applyOrder(ob, newTestOrder("b1", models.Buy, models.Limit, 101, 2))
+	if len(trades) != 2 || trades[0].MakerId != "a2" || trades[1].MakerId != "a3" {
+		t.Errorf("all or none maker should be skipped: %+v", trades)
+	}
+
+	// 可以立即成交的数量不足最小成交数量，整单撤销
+	ioc := newTestOrder("b2", models.Buy, models.Limit, 100, 3)
+	ioc.TimeInForce = models.TimeInForceIOC
+	ioc.MinQty = decimal.NewFromInt(3)
+	trades = applyOrder(ob, ioc)
+	if len(trades) != 1 || trades[0].TakerOrderType != models.Cancel || trades[0].Reason != models.ReasonMinQty {
+		t.Errorf("min qty IOC should be cancelled: %+v", trades)
+	}
+
+	// 数量足够时全部成交单成交
+	trades = applyOrder(ob, newTestOrder("b3", models.Buy, models.Limit, 100, 5))
+	if len(trades) != 1 || trades[0].MakerId != "a1" || trades[0].Amount != "5" {
+		t.Errorf("all or none maker should be filled: %+v", trades)
+	}
+
+	// 全部成交的taker不能全部成交时直接挂单
+	taker := newTestOrder("b4", models.Buy, models.Limit, 101, 3)
+	taker.AllOrNone = true
+	if trades = applyOrder(ob, taker); len(trades) != 0 || ob.bid.First() == nil || ob.bid.First().Value().GetId() != "b4" {
+		t.Errorf("all or none taker should rest: %+v", trades)
+	}
+
+	// GTC订单有对手盘但不足最小成交数量，整单撤销
+	gtc := newTestOrder("b5", models.Buy, models.Limit, 101, 2)
+	gtc.MinQty = decimal.NewFromInt(2)
+	trades = applyOrder(ob, gtc)
+	if len(trades) != 1 || trades[0].Reason != models.ReasonMinQty || ob.ask.First() == nil {
+		t.Errorf("min qty GTC should be cancelled: %+v", trades)
+	}
+
+	invalid := newTestOrder("b6", models.Buy, models.Limit, 100, 1)
+	invalid.TimeInForce = models.TimeInForceIOC
+	invalid.AllOrNone = true
+	if err := validate(&invalid); !errors.Is(err, ErrAllOrNone) {
+		t.Errorf("all or none IOC should be rejected: %v", err)
+	}
+	invalid = newTestOrder("b7", models.Buy, models.Limit, 100, 1)
+	invalid.MinQty = decimal.NewFromInt(2)
+	if err := validate(&invalid); !errors.Is(err, ErrMinQty) {
+		t.Errorf("min qty above amount should be rejected: %v", err)
+	}
+}
+
+// testPosition 测试用的持仓接口
+type testPosition map[int64]decimal.Decimal
+
+func (p testPosition) GetPosition(pair string, userId int64) decimal.Decimal {
+	return p[userId]
+}
+
+func TestOrderbook_ReduceOnly(t *testing.T) {
+	ob, _ := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+	positions := testPosition{1: decimal.NewFromInt(5)}
+	ob.provider = positions
+	handleOrder := func(order models.Order) []models.Trade {
+		trades, _ := ob.handle(&journal.Entry{Op: journal.OpAdd, Order: &order})
+		return trades
+	}
+
+	// 多仓不能买入减仓
+	buy := newTestOrder("a1", models.Buy, models.Limit, 99, 1)
+	buy.ReduceOnly = true
+	if trades := handleOrder(buy); len(trades) != 1 || trades[0].Reason != models.ReasonReduceOnly || ob.bid.First() != nil {
+		t.Errorf("reduce only buy should be rejected: %+v", trades)
+	}
+
+	// 超出持仓的部分撤销，之后的只减仓订单扣除盘口中已有的数量
+	sell := newTestOrder("a2", models.Sell, models.Limit, 102, 8)
+	sell.ReduceOnly = true
+	if trades := handleOrder(sell); len(trades) != 1 || trades[0].Amount != "3" || ob.ask.First().Value().GetAmount().String() != "5" {
+		t.Errorf("reduce only sell should be capped: %+v", trades)
+	}
+	// 已记录持仓时按成交累计，不再查询持仓接口
+	positions[1] = decimal.NewFromInt(100)
+	sell = newTestOrder("a3", models.Sell, models.Limit, 103, 1)
+	sell.ReduceOnly = true
+	if trades := handleOrder(sell); len(trades) != 1 || trades[0].Reason != models.ReasonReduceOnly {
+		t.Errorf("reduce only sell above position should be rejected: %+v", trades)
+	}
+
+	// 平仓止损单的数量为持仓
+	stop := newTestOrder("a4", models.Sell, models.StopMarket, 0, 0)
+	stop.TriggerPrice = decimal.NewFromInt(90)
+	stop.ClosePosition = true
+	handleOrder(stop)
+	if node := ob.stopAsk.First(); node == nil || node.Value().GetAmount().String() != "5" {
+		t.Errorf("close position stop amount should be position")
+	}
+
+	// 持仓减少后减少只减仓订单的数量
+	maker := newTestOrder("b1", models.Buy, models.Limit, 100, 2)
+	maker.UserId = 2
+	handleOrder(maker)
+	trades := handleOrder(newTestOrder("a5", models.Sell, models.Limit, 100, 2))
+	if len(trades) != 3 || trades[1].TakerOrderType != models.Amend || trades[1].Amount != "3" || trades[2].Amount != "3" ||
+		ob.ask.First().Value().GetAmount().String() != "3" || ob.stopAsk.First().Value().GetAmount().String() != "3" {
+		t.Errorf("reduce only orders should be reduced: %+v", trades)
+	}
+
+	// 平仓后撤销全部只减仓订单
+	positions[1] = decimal.Zero
+	trades, _ = ob.handle(&journal.Entry{Op: journal.OpPosition, UserId: 1})
+	if len(trades) != 2 || ob.ask.First() != nil || ob.stopAsk.First() != nil || len(ob.held) != 0 {
+		t.Errorf("reduce only orders should be cancelled: %+v", trades)
+	}
+
+	invalid := newTestOrder("a6", models.Sell, models.Limit, 100, 0)
+	invalid.ClosePosition = true
+	if err := validate(&invalid); !errors.Is(err, ErrReduceOnly) {
+		t.Errorf("close position limit order should be rejected: %v", err)
+	}
+	ob.provider = nil
+	if err := ob.Add(&sell); !errors.Is(err, ErrPosition) {
+		t.Errorf("reduce only order without position provider should be rejected: %v", err)
+	}
+}
+
+func TestOrderbook_Allocation(t *testing.T) {
+	amounts := func(trades []models.Trade) string {
+		result := make([]string, 0, len(trades))
+		for _, trade := range trades {
+			result = append(result, trade.MakerId+":"+trade.Amount)
+		}
+		return fmt.Sprint(result)
+	}
+
+	// 按比例分配，取整剩下的数量按时间优先分配
+	ob, _ := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair, LotSize: decimal.NewFromInt(1), Allocation: conf.AllocationProRata}, &mq.YourMq{})
+	applyOrder(ob, newTestOrder("a1", models.Sell, models.Limit, 100, 2))
+	applyOrder(ob, newTestOrder("a2", models.Sell, models.Limit, 100, 6))
+	applyOrder(ob, newTestOrder("a3", models.Sell, models.Limit, 100, 2))
+	applyOrder(ob, newTestOrder("a4", models.Sell, models.Limit, 101, 1))
+	if result := amounts(applyOrder(ob, newTestOrder("b1", models.Buy, models.Limit, 101, 5))); result != "[a1:1 a2:3 a3:1]" {
+		t.Errorf("pro rata allocation error: %s", result)
+	}
+	if result := amounts(applyOrder(ob, newTestOrder("b2", models.Buy, models.Limit, 101, 6))); result != "[a1:1 a2:3 a3:1 a4:1]" {
+		t.Errorf("taker should sweep the level in time priority: %s", result)
+	}
+
+	// 时间最早的订单先分配25%，剩余部分按比例分配
+	ob, _ = NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair, LotSize: decimal.NewFromInt(1), Allocation: conf.AllocationHybrid,
+		AllocationTop: decimal.RequireFromString("0.25")}, &mq.YourMq{})
+	applyOrder(ob, newTestOrder("a1", models.Sell, models.Limit, 100, 8))
+	applyOrder(ob, newTestOrder("a2", models.Sell, models.Limit, 100, 8))
+	if result := amounts(applyOrder(ob, newTestOrder("b1", models.Buy, models.Limit, 100, 8))); result != "[a1:5 a2:3]" {
+		t.Errorf("hybrid allocation error: %s", result)
+	}
+
+	if err := checkPair(conf.Pair{Symbol: pair, Allocation: conf.AllocationHybrid}); !errors.Is(err, ErrPairSpec) {
+		t.Errorf("hybrid allocation without top percent should be rejected: %v", err)
+	}
+}
+
+func TestOrderbook_Bracket(t *testing.T) {
+	ob, _ := NewOrderbook(status.NewStatus(), conf.Pair{Symbol: pair}, &mq.YourMq{})
+	entry := newTestOrder("e1", models.Buy, models.Limit, 100, 5)
+	profit := newTestOrder("tp1", models.Sell, models.Limit, 110, 5)
+	stop := newTestOrder("sl1", models.Sell, models.StopMarket, 0, 5)
+	stop.TriggerPrice = decimal.NewFromInt(90)
+	ob.apply(&journal.Entry{Op: journal.OpBracket, Order: &entry, Profit: &profit, Link: &stop})
+	if ob.ask.First() != nil || ob.stopAsk.First() != nil {
+		t.Errorf("children should not be active before the parent fills")
+	}
+	taker := func(id string, side string, price int64, amount int64) []models.Trade {
+		order := newTestOrder(id, side, models.Limit, price, amount)
+		order.UserId = 2
+		return applyOrder(ob, order)
+	}
+
+	// 父订单部分成交，按成交数量激活子订单
+	taker("s1", models.Sell, 100, 2)
+	if node := ob.ask.First(); node == nil || node.Value().GetId() != "tp1" || node.Value().GetAmount().String() != "2" ||
+		ob.stopAsk.First() == nil || ob.stopAsk.First().Value().GetAmount().String() != "2" {
+		t.Errorf("children should be activated with the filled amount")
+	}
+
+	// 父订单再次成交，增加子订单数量
+	taker("s2", models.Sell, 100, 1)
+	if ob.ask.First().Value().GetAmount().String() != "3" || ob.stopAsk.First().Value().GetAmount().String() != "3" {
+		t.Errorf("children should grow with the parent fills")
+	}
+
+	// 止盈单成交，撤销止损单和父订单剩余部分
+	trades := taker("b1", models.Buy, 110, 3)
+	cancelled := false
+	for _, trade := range trades {
+		if trade.MakerId == "e1" && trade.Reason == models.ReasonBracket && trade.Amount == "2" {
+			cancelled = true
+		}
+	}
+	if !cancelled || ob.bid.First() != nil || ob.stopAsk.First() != nil || len(ob.brackets) != 0 {
+		t.Errorf("parent should be cancelled after the children finish: %+v", trades)
+	}
+
+	profit.Side = models.Buy
+	stop.Side = models.Buy
+	if err := checkBracket(&entry, &profit, &stop); !errors.Is(err, ErrBracket) {
+		t.Errorf("children on the parent side should be rejected: %v", err)
+	}
+}
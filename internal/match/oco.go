@@ -0,0 +1,111 @@
+package match
+
+import (
+	"lightning-engine/models"
+	"time"
+)
+
+// AddOco 同步挂OCO订单，limit为GTC/GTD限价单(止盈)，stop为止损单，返回立即成交的成交单。
+// 任意一方成交(包括部分成交)、触发或撤销时，在同一条指令中撤销另一方
+func (ob *Orderbook) AddOco(limit *models.Order, stop *models.Order) ([]models.Trade, error) {
+	for _, order := range []*models.Order{limit, stop} {
+		if err := validate(order); err != nil {
+			return nil, err
+		}
+		if err := ob.check(order); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkOco(limit, stop); err != nil {
+		return nil, err
+	}
+	ob.status.Add(1)
+	defer ob.status.Done()
+	link := *stop
+	req := addRequest{order: *limit, link: &link, reply: make(chan addResult, 1)}
+	if err := ob.submit(func() error {
+		select {
+		case ob.chAdd <- req:
+			return nil
+		case <-time.After(time.Second):
+			return ErrTimeout
+		case <-ob.status.Context().Done():
+			return ErrClosed
+		}
+	}); err != nil {
+		return nil, err
+	}
+	select {
+	case result := <-req.reply:
+		return result.trades, result.err
+	case <-time.After(time.Second):
+		return nil, ErrTimeout
+	case <-ob.status.Context().Done():
+		return nil, ErrClosed
+	}
+}
+
+// checkOco 校验OCO订单的两方
+func checkOco(limit *models.Order, stop *models.Order) error {
+	if limit.Type != models.Limit || (limit.TimeInForce != models.TimeInForceGTC && limit.TimeInForce != models.TimeInForceGTD) || !stop.IsStop() {
+		return ErrOco
+	}
+	if limit.Id == stop.Id || limit.Pair != stop.Pair || limit.UserId != stop.UserId || limit.Side != stop.Side {
+		return ErrOco
+	}
+	return nil
+}
+
+// addOco 挂OCO订单，先挂止损单再挂限价单。止损单被拒绝时撤销限价单，限价单挂单时成交则撤销止损单
+func (ob *Orderbook) addOco(limit models.Order, stop models.Order) ([]models.Trade, error) {
+	limit.LinkId, stop.LinkId = stop.Id, limit.Id
+	ob.links[limit.Id], ob.links[stop.Id] = stop.Id, limit.Id
+	trades, err := ob.add(stop)
+	trades = append(trades, ob.unlinkTrades(trades)...)
+	if _, ok := ob.links[limit.Id]; !ok || err != nil {
+		delete(ob.links, limit.Id)
+		delete(ob.links, stop.Id)
+		return append(trades, cancelTrade(&limit, models.ReasonOco)), err
+	}
+	result, err := ob.add(limit)
+	trades = append(trades, result...)
+	return append(trades, ob.unlinkTrades(result)...), err
+}
+
+// unlinkTrades 成交单中成交、触发或撤销的OCO订单，撤销其关联订单，返回撤销记录
+func (ob *Orderbook) unlinkTrades(trades []models.Trade) []models.Trade {
+	if len(ob.links) == 0 {
+		return nil
+	}
+	result := make([]models.Trade, 0)
+	for _, trade := range trades {
+		if trade.TakerOrderType == models.Amend || trade.TakerOrderType == models.Trail { // 改单和跟踪止损更新不撤销关联订单
+			continue
+		}
+		// 成交时maker和taker都可能是OCO订单，撤销、触发等事件记录的maker和taker为同一订单
+		result = append(result, ob.unlink(trade.MakerId)...)
+		result = append(result, ob.unlink(trade.TakerId)...)
+	}
+	return result
+}
+
+// unlink 解除id的OCO关联并撤销关联订单，关联订单已不在盘口和触发簿中时只解除关联
+func (ob *Orderbook) unlink(id string) []models.Trade {
+	sibling, ok := ob.links[id]
+	if !ok {
+		return nil
+	}
+	delete(ob.links, id)
+	delete(ob.links, sibling)
+	s := ob.locate(sibling)
+	if s == nil {
+		return nil
+	}
+	node, _ := s.orders.Find(s.index[sibling], sibling)
+	if node == nil {
+		return nil
+	}
+	order := node.Value().(*models.Order)
+	remove(s.orders, s.index, node)
+	return []models.Trade{eventTrade(order, models.Cancel, order.TotalAmount(), models.ReasonOco)}
+}
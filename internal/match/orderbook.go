@@ -4,30 +4,71 @@ import (
 	"errors"
 	"fmt"
 	"github.com/shopspring/decimal"
+	"lightning-engine/internal/conf"
+	"lightning-engine/internal/journal"
+	"lightning-engine/internal/snapshot"
 	"lightning-engine/internal/status"
 	"lightning-engine/models"
 	"lightning-engine/mq"
+	"lightning-engine/position"
 	"lightning-engine/pqueue/skiplist"
 	"lightning-engine/utils"
+	"log"
+	"sync"
 	"time"
 )
 
 // Orderbook 盘口订单簿
 type Orderbook struct {
 	pair string
+	spec conf.Pair              // 交易对配置
 	bid  *skiplist.SkipListDesc // bid从大到小排列
 	ask  *skiplist.SkipList     // ask从小到大排列
 
 	mBid map[string]decimal.Decimal // bid订单id对应的score
 	mAsk map[string]decimal.Decimal // ask订单id对应的score
 
+	stopBid   *skiplist.SkipList         // bid止损单，按触发价从小到大排列
+	stopAsk   *skiplist.SkipListDesc     // ask止损单，按触发价从大到小排列
+	mStopBid  map[string]decimal.Decimal // bid止损单id对应的触发价
+	mStopAsk  map[string]decimal.Decimal // ask止损单id对应的触发价
+	lastPrice decimal.Decimal            // 最新成交价
+	phase     string                     // 交易阶段
+	breaker   breaker                    // 熔断状态
+	now       int64                      // 当前指令的时间(毫秒时间戳)，回放日志时为写入时间
+
+	expiry expiryHeap // GTD订单按过期时间排列的堆，订单成交或撤销后延迟删除
+
+	links    map[string]string   // OCO订单id对应的关联订单id，双向记录
+	brackets map[string]*bracket // 括号订单的父订单和子订单id对应的括号订单，子订单结束后删除
+
+	trails map[string]struct{} // 跟踪止损单id，止损单触发或撤销后延迟删除
+	pegs   map[string]struct{} // 挂钩单id，订单成交或撤销后延迟删除
+
+	provider position.IPosition            // 持仓接口，为nil时不接受只减仓订单
+	held     map[int64]decimal.Decimal     // 只减仓订单用户的持仓，查询持仓后按本交易对的成交累计
+	reduces  map[int64]map[string]struct{} // 用户的只减仓订单id，订单成交或撤销后延迟删除
+
+	journal     *journal.Journal // 预写日志，为nil时不落盘
+	replaying   bool             // 是否正在回放日志，回放时不推送成交单
+	data        *conf.Data       // 持久化配置
+	snapshotSeq int64            // 最近一次快照的日志序列号
+
 	mq       mq.IMQ
-	chAdd    chan models.Order // order channel 异步顺序处理订单
+	chAdd    chan addRequest   // order channel 异步顺序处理订单
 	chCancel chan string       // order_id channel 异步顺序处理订单
+	chAmend  chan addRequest   // 改单 channel，同步返回改单结果
+	chMass   chan massRequest  // 批量撤单 channel，同步返回撤销的订单
+	chDepth  chan depthRequest // 深度查询 channel，在撮合协程中读取盘口
+	chAdmin  chan adminRequest // 交易对管理 channel，暂停、恢复、下架
 	status   *status.Status    // 程序退出状态
+
+	sending sync.RWMutex  // 发送指令时加读锁，撮合协程停止接收指令时加写锁
+	stopped bool          // 撮合协程是否已停止接收指令(下架或程序退出)
+	done    chan struct{} // 撮合协程处理完剩余指令、生成快照并关闭日志后关闭
 }
 
-func NewOrderbook(status *status.Status, pair string, mq mq.IMQ) (*Orderbook, error) {
+func NewOrderbook(status *status.Status, pair conf.Pair, mq mq.IMQ) (*Orderbook, error) {
 	if mq == nil {
 		return nil, ErrMq
 	}
@@ -39,805 +80,1007 @@ func NewOrderbook(status *status.Status, pair string, mq mq.IMQ) (*Orderbook, er
 	if err != nil {
 		return nil, err
 	}
+	stopBid, err := skiplist.NewSkipList()
+	if err != nil {
+		return nil, err
+	}
+	stopAsk, err := skiplist.NewSkipListDesc()
+	if err != nil {
+		return nil, err
+	}
 	return &Orderbook{
-		pair:     pair,
+		pair:     pair.Symbol,
+		spec:     pair,
 		bid:      bid,
 		ask:      ask,
 		mBid:     make(map[string]decimal.Decimal),
 		mAsk:     make(map[string]decimal.Decimal),
+		stopBid:  stopBid,
+		stopAsk:  stopAsk,
+		mStopBid: make(map[string]decimal.Decimal),
+		mStopAsk: make(map[string]decimal.Decimal),
+		phase:    models.PhaseContinuous,
+		links:    make(map[string]string),
+		brackets: make(map[string]*bracket),
+		trails:   make(map[string]struct{}),
+		pegs:     make(map[string]struct{}),
+		held:     make(map[int64]decimal.Decimal),
+		reduces:  make(map[int64]map[string]struct{}),
 		mq:       mq,
-		chAdd:    make(chan models.Order, 1000000),
+		chAdd:    make(chan addRequest, 1000000),
 		chCancel: make(chan string, 1000000),
+		chAmend:  make(chan addRequest, 1000),
+		chMass:   make(chan massRequest, 1000),
+		chDepth:  make(chan depthRequest, 1000),
+		chAdmin:  make(chan adminRequest, 100),
 		status:   status,
+		done:     make(chan struct{}),
 	}, nil
 }
 
 // Add 异步挂单
 func (ob *Orderbook) Add(order *models.Order) error {
+	if err := validate(order); err != nil {
+		return err
+	}
+	if err := ob.check(order); err != nil {
+		return err
+	}
+	ob.status.Add(1)
+	defer ob.status.Done()
+	return ob.submit(func() error {
+		select {
+		case ob.chAdd <- addRequest{order: *order}:
+			return nil
+		case <-time.After(time.Second):
+			return ErrTimeout
+		case <-ob.status.Context().Done():
+			return ErrClosed
+		}
+	})
+}
+
+// AddSync 同步挂单，等待撮合协程处理完成，返回立即成交的成交单，订单被拒绝时返回错误
+func (ob *Orderbook) AddSync(order *models.Order) ([]models.Trade, error) {
+	if err := validate(order); err != nil {
+		return nil, err
+	}
+	if err := ob.check(order); err != nil {
+		return nil, err
+	}
 	ob.status.Add(1)
 	defer ob.status.Done()
+	req := addRequest{order: *order, reply: make(chan addResult, 1)}
+	if err := ob.submit(func() error {
+		select {
+		case ob.chAdd <- req:
+			return nil
+		case <-time.After(time.Second):
+			return ErrTimeout
+		case <-ob.status.Context().Done():
+			return ErrClosed
+		}
+	}); err != nil {
+		return nil, err
+	}
 	select {
-	case ob.chAdd <- *order:
-		return nil
+	case result := <-req.reply:
+		return result.trades, result.err
 	case <-time.After(time.Second):
-		return ErrTimeout
+		return nil, ErrTimeout
 	case <-ob.status.Context().Done():
-		return ErrClosed
+		return nil, ErrClosed
+	}
+}
+
+// Accepted 根据同步挂单返回的成交单判断订单是否被撮合引擎接受，返回拒绝原因。
+// 订单没有成交且被整单撤销(只做maker拒绝、最小成交数量不足、止损单拒绝、只减仓拒绝等)时视为拒绝
+func Accepted(order *models.Order, trades []models.Trade) (bool, string) {
+	cancelled := decimal.Zero
+	reason, found := "", false
+	for _, trade := range trades {
+		if trade.IsFill() && (trade.MakerId == order.Id || trade.TakerId == order.Id) {
+			return true, ""
+		}
+		if trade.TakerOrderType != models.Cancel || trade.TakerId != order.Id || trade.MakerId != order.Id {
+			continue
+		}
+		if !found {
+			reason, found = trade.Reason, true
+		}
+		if trade.Reason != models.ReasonQuoteUnspent { // 未花完的金额不是订单数量
+			cancelled = cancelled.Add(decimal.RequireFromString(trade.Amount))
+		}
 	}
+	if found && cancelled.GreaterThanOrEqual(order.Amount) {
+		return false, reason
+	}
+	return true, ""
 }
 
 // Cancel 异步撤单
 func (ob *Orderbook) Cancel(id string) error {
 	ob.status.Add(1)
 	defer ob.status.Done()
+	return ob.submit(func() error {
+		select {
+		case ob.chCancel <- id:
+			return nil
+		case <-time.After(time.Second):
+			return ErrTimeout
+		case <-ob.status.Context().Done():
+			return ErrClosed
+		}
+	})
+}
+
+// Depth 查询盘口深度，请求交给撮合协程处理，不与撮合并发读写跳表
+func (ob *Orderbook) Depth(levels int) (*models.Depth, error) {
+	ob.status.Add(1)
+	defer ob.status.Done()
+	req := depthRequest{levels: levels, reply: make(chan *models.Depth, 1)}
+	if err := ob.submit(func() error {
+		select {
+		case ob.chDepth <- req:
+			return nil
+		case <-time.After(time.Second):
+			return ErrTimeout
+		case <-ob.status.Context().Done():
+			return ErrClosed
+		}
+	}); err != nil {
+		return nil, err
+	}
 	select {
-	case ob.chCancel <- id:
-		return nil
+	case depth := <-req.reply:
+		return depth, nil
 	case <-time.After(time.Second):
-		return ErrTimeout
+		return nil, ErrTimeout
 	case <-ob.status.Context().Done():
-		return ErrClosed
+		return nil, ErrClosed
+	}
+}
+
+// Recover 从最新快照和快照之后的日志恢复盘口，之后处理的指令都会先写入日志
+func (ob *Orderbook) Recover(data *conf.Data) error {
+	snap, err := snapshot.Latest(data.Dir, ob.pair)
+	if err != nil {
+		return err
+	}
+	if snap != nil {
+		ob.restore(snap)
+	}
+
+	j, err := journal.Open(data.Dir, ob.pair, data.Sync)
+	if err != nil {
+		return err
+	}
+	ob.replaying = true
+	defer func() { ob.replaying = false }()
+	seq := ob.snapshotSeq
+	err = j.Replay(seq, func(e *journal.Entry) error {
+		if e.Seq != seq+1 { // 快照和日志之间不能有缺失
+			return journal.ErrSeq
+		}
+		seq = e.Seq
+		ob.apply(e)
+		return nil
+	})
+	if err != nil {
+		j.Close()
+		return err
+	}
+	ob.journal = j
+	ob.data = data
+	return nil
+}
+
+// restore 从快照还原盘口，快照中的订单已按价格时间排序，按顺序插入即保持原有优先级
+func (ob *Orderbook) restore(snap *snapshot.Snapshot) {
+	for i := range snap.Bids {
+		order := &snap.Bids[i]
+		if order.Peg != "" {
+			ob.pegs[order.Id] = struct{}{}
+		}
+		ob.bid.Insert(order.Price, order)
+		ob.mBid[order.Id] = order.Price
+	}
+	for i := range snap.Asks {
+		order := &snap.Asks[i]
+		if order.Peg != "" {
+			ob.pegs[order.Id] = struct{}{}
+		}
+		ob.ask.Insert(order.Price, order)
+		ob.mAsk[order.Id] = order.Price
+	}
+	for i := range snap.Stops {
+		order := &snap.Stops[i]
+		if order.IsTrailing() {
+			ob.trails[order.Id] = struct{}{}
+		}
+		if order.Side == models.Buy {
+			ob.stopBid.Insert(order.TriggerPrice, order)
+			ob.mStopBid[order.Id] = order.TriggerPrice
+		} else {
+			ob.stopAsk.Insert(order.TriggerPrice, order)
+			ob.mStopAsk[order.Id] = order.TriggerPrice
+		}
+	}
+	for _, orders := range [][]models.Order{snap.Bids, snap.Asks, snap.Stops} {
+		for i := range orders {
+			ob.schedule(&orders[i])
+			if orders[i].IsReduceOnly() {
+				ob.mark(&orders[i])
+			}
+			if orders[i].LinkId != "" && ob.locate(orders[i].LinkId) != nil { // 关联订单已成交或撤销时不再关联
+				ob.links[orders[i].Id] = orders[i].LinkId
+			}
+		}
+	}
+	ob.lastPrice = snap.LastPrice
+	if snap.Phase != "" {
+		ob.phase = snap.Phase
 	}
+	ob.breaker = breaker{lows: snap.BreakerLows, highs: snap.BreakerHighs, until: snap.BreakerUntil}
+	for userId, position := range snap.Positions {
+		ob.held[userId] = position
+	}
+	for _, s := range snap.Brackets {
+		b := &bracket{parent: s.Parent, profit: s.Profit, stop: s.Stop, active: s.Active}
+		ob.brackets[b.parent], ob.brackets[b.profit.Id], ob.brackets[b.stop.Id] = b, b, b
+	}
+	ob.snapshotSeq = snap.Header.Seq
+}
+
+// snapshot 生成盘口快照并归档日志，只保留最近两份快照及其之后的日志
+func (ob *Orderbook) snapshot() error {
+	if ob.journal == nil || ob.journal.Seq() == ob.snapshotSeq {
+		return nil
+	}
+	snap := &snapshot.Snapshot{
+		Header:    snapshot.Header{Pair: ob.pair, Seq: ob.journal.Seq(), Ts: utils.NowUnixMilli()},
+		Bids:      make([]models.Order, 0),
+		Asks:      make([]models.Order, 0),
+		Stops:     make([]models.Order, 0),
+		LastPrice: ob.lastPrice,
+		Phase:     ob.phase,
+
+		BreakerLows:  ob.breaker.lows,
+		BreakerHighs: ob.breaker.highs,
+		BreakerUntil: ob.breaker.until,
+
+		Positions: ob.held,
+		Brackets:  ob.snapshotBrackets(),
+	}
+	for p := ob.bid.First(); p != nil; p = p.Next(0) {
+		snap.Bids = append(snap.Bids, *p.Value().(*models.Order))
+	}
+	for p := ob.ask.First(); p != nil; p = p.Next(0) {
+		snap.Asks = append(snap.Asks, *p.Value().(*models.Order))
+	}
+	for p := ob.stopBid.First(); p != nil; p = p.Next(0) {
+		snap.Stops = append(snap.Stops, *p.Value().(*models.Order))
+	}
+	for p := ob.stopAsk.First(); p != nil; p = p.Next(0) {
+		snap.Stops = append(snap.Stops, *p.Value().(*models.Order))
+	}
+	if _, err := snapshot.Write(ob.data.Dir, snap); err != nil {
+		return err
+	}
+	ob.snapshotSeq = snap.Header.Seq
+	if err := ob.journal.Rotate(); err != nil {
+		return err
+	}
+	seq, err := snapshot.Purge(ob.data.Dir, ob.pair, 2)
+	if err != nil {
+		return err
+	}
+	return ob.journal.Purge(seq)
 }
 
 // Begin 开始撮合
 func (ob *Orderbook) Begin() {
 	defer ob.status.Done()
+	defer close(ob.done)
+	defer ob.closeJournal()
+	defer func() {
+		if err := ob.snapshot(); err != nil {
+			log.Printf("[%s] snapshot error: %v\n", ob.pair, err)
+		}
+	}()
+	ticker := time.NewTicker(expireInterval)
+	defer ticker.Stop()
 	for {
 		select {
-		case order := <-ob.chAdd:
-			ob.add(order)
+		case req := <-ob.chAdd:
+			ob.handleAdd(req)
 		case orderId := <-ob.chCancel:
-			ob.cancel(orderId)
+			ob.handle(&journal.Entry{Op: journal.OpCancel, Id: orderId})
+		case req := <-ob.chAmend:
+			ob.handleAmend(req)
+		case req := <-ob.chMass:
+			ob.handleCancelAll(req)
+		case req := <-ob.chDepth:
+			req.reply <- ob.depth(req.levels)
+		case <-ticker.C:
+			ob.handleExpire(utils.NowUnixMilli())
+			ob.handleBreaker(utils.NowUnixMilli())
+		case req := <-ob.chAdmin:
+			req.reply <- ob.handleAdmin(req)
+			if ob.phase == models.PhaseDelisted { // 下架后处理完已接收的指令，退出撮合协程
+				ob.stop()
+				return
+			}
 		case <-ob.status.Context().Done():
+			ob.stop()
+			return
+		}
+	}
+}
+
+// submit 撮合协程停止接收指令前发送指令，已停止时返回ErrDelisted或ErrClosed，不发送到没有读取的channel
+func (ob *Orderbook) submit(send func() error) error {
+	ob.sending.RLock()
+	defer ob.sending.RUnlock()
+	if ob.stopped {
+		if ob.status.Context().Err() != nil {
+			return ErrClosed
+		}
+		return ErrDelisted
+	}
+	return send()
+}
+
+// stop 停止接收指令，等待正在发送的指令完成后处理channel中剩余的指令
+func (ob *Orderbook) stop() {
+	ob.sending.Lock()
+	ob.stopped = true
+	ob.sending.Unlock()
+	ob.drain()
+}
+
+// drain 退出前处理完channel中已接收的指令，避免已返回成功的订单丢失
+func (ob *Orderbook) drain() {
+	for {
+		select {
+		case req := <-ob.chAdd:
+			ob.handleAdd(req)
+		case orderId := <-ob.chCancel:
+			ob.handle(&journal.Entry{Op: journal.OpCancel, Id: orderId})
+		case req := <-ob.chAmend:
+			ob.handleAmend(req)
+		case req := <-ob.chMass:
+			ob.handleCancelAll(req)
+		case req := <-ob.chDepth:
+			req.reply <- ob.depth(req.levels)
+		case req := <-ob.chAdmin:
+			req.reply <- ob.handleAdmin(req)
+		default:
 			return
 		}
 	}
 }
 
-// add 挂单
-func (ob *Orderbook) add(order models.Order) error {
+// handleAdd 处理挂单请求，同步挂单时返回撮合结果
+func (ob *Orderbook) handleAdd(req addRequest) {
+	if reason, err := ob.tradable(); err != nil { // 暂停或下架时拒绝，不写日志
+		trades := []models.Trade{cancelTrade(&req.order, reason)}
+		if req.profit != nil {
+			trades = append(trades, cancelTrade(req.profit, reason))
+		}
+		if req.link != nil {
+			trades = append(trades, cancelTrade(req.link, reason))
+		}
+		ob.PushTrades(trades...)
+		if req.reply != nil {
+			req.reply <- addResult{trades: trades, err: err}
+		}
+		return
+	}
+	e := &journal.Entry{Op: journal.OpAdd, Order: &req.order}
+	if req.profit != nil {
+		e = &journal.Entry{Op: journal.OpBracket, Order: &req.order, Profit: req.profit, Link: req.link}
+	} else if req.link != nil {
+		e = &journal.Entry{Op: journal.OpOco, Order: &req.order, Link: req.link}
+	}
+	trades, err := ob.handle(e)
+	if req.reply != nil {
+		req.reply <- addResult{trades: trades, err: err}
+	}
+}
+
+// handle 先写日志再执行指令，写日志失败时放弃执行，保证盘口可以从日志恢复
+func (ob *Orderbook) handle(e *journal.Entry) ([]models.Trade, error) {
+	if e.Ts == 0 {
+		e.Ts = utils.NowUnixMilli()
+	}
+	ob.query(e)
+	if ob.journal != nil {
+		if err := ob.journal.Append(e); err != nil {
+			log.Printf("[%s] write journal error: %v, entry: %+v\n", ob.pair, err, e)
+			return nil, ErrJournal
+		}
+	}
+	trades, err := ob.apply(e)
+	if ob.phase == models.PhaseAuction {
+		ob.indicate()
+	}
+
+	// 定期生成快照，缩短重启后回放日志的时间
+	if ob.journal != nil && ob.data.SnapshotInterval > 0 && ob.journal.Seq()-ob.snapshotSeq >= ob.data.SnapshotInterval {
+		if err := ob.snapshot(); err != nil {
+			log.Printf("[%s] snapshot error: %v\n", ob.pair, err)
+		}
+	}
+	return trades, err
+}
+
+// apply 执行指令，处理括号订单，持仓变化时调整只减仓订单，最优价变化时重新定价挂钩单，返回产生的成交单和调整、改价记录
+func (ob *Orderbook) apply(e *journal.Entry) ([]models.Trade, error) {
+	ob.now = e.Ts
+	ob.hold(e)
+	trades, err := ob.execute(e)
+	trades = append(trades, ob.settle(trades)...)
+	if reduced := ob.reduce(e, trades); len(reduced) > 0 {
+		ob.PushTrades(reduced...)
+		trades = append(trades, reduced...)
+	}
+	if repriced := ob.repeg(); len(repriced) > 0 {
+		ob.PushTrades(repriced...)
+		trades = append(trades, repriced...)
+	}
+	return trades, err
+}
+
+// execute 按指令类型执行指令
+func (ob *Orderbook) execute(e *journal.Entry) ([]models.Trade, error) {
+	switch e.Op {
+	case journal.OpAdd:
+		trades, err := ob.add(*e.Order)
+		trades = append(trades, ob.unlinkTrades(trades)...)
+		ob.setLastPrice(trades)
+		trades = append(trades, ob.trail(trades)...)
+		trades = append(trades, ob.trigger()...)
+		if len(trades) > 0 {
+			ob.PushTrades(trades...)
+		}
+		return trades, err
+	case journal.OpBracket:
+		trades, err := ob.addBracket(*e.Order, *e.Profit, *e.Link)
+		trades = append(trades, ob.unlinkTrades(trades)...)
+		ob.setLastPrice(trades)
+		trades = append(trades, ob.trail(trades)...)
+		trades = append(trades, ob.trigger()...)
+		if len(trades) > 0 {
+			ob.PushTrades(trades...)
+		}
+		return trades, err
+	case journal.OpOco:
+		trades, err := ob.addOco(*e.Order, *e.Link)
+		ob.setLastPrice(trades)
+		trades = append(trades, ob.trail(trades)...)
+		trades = append(trades, ob.trigger()...)
+		if len(trades) > 0 {
+			ob.PushTrades(trades...)
+		}
+		return trades, err
+	case journal.OpCancel:
+		if err := ob.cancel(e.Id); err != nil {
+			return nil, err
+		}
+		trades := ob.unlink(e.Id)
+		trades = append(trades, ob.cancelBracket(e.Id)...)
+		if len(trades) > 0 {
+			ob.PushTrades(trades...)
+		}
+		return trades, nil
+	case journal.OpAmend:
+		trades, err := ob.amend(*e.Order)
+		trades = append(trades, ob.unlinkTrades(trades)...)
+		ob.setLastPrice(trades)
+		trades = append(trades, ob.trail(trades)...)
+		trades = append(trades, ob.trigger()...)
+		if len(trades) > 0 {
+			ob.PushTrades(trades...)
+		}
+		return trades, err
+	case journal.OpCancelAll:
+		return ob.cancelAll(e.UserId, e.Side), nil
+	case journal.OpExpire:
+		return ob.expire(e.Time), nil
+	case journal.OpHalt, journal.OpResume, journal.OpDelist, journal.OpRelist, journal.OpAuction, journal.OpUncross:
+		return ob.lifecycle(e.Op), nil
+	case journal.OpPosition: // 只更新持仓，由apply调整只减仓订单
+		return nil, nil
+	}
+	return nil, ErrJournalOp
+}
+
+// closeJournal 关闭日志
+func (ob *Orderbook) closeJournal() {
+	if ob.journal == nil {
+		return
+	}
+	if err := ob.journal.Close(); err != nil {
+		log.Printf("[%s] close journal error: %v\n", ob.pair, err)
+	}
+}
+
+// validate 校验订单字段，不合法的订单不进入撮合
+func validate(order *models.Order) error {
+	if order.Id == "" {
+		return ErrOrderId
+	}
+	if order.Side != models.Buy && order.Side != models.Sell {
+		return ErrOrderSide
+	}
+	if order.QuoteAmount.IsZero() {
+		if !order.Amount.IsPositive() && !order.ClosePosition {
+			return ErrOrderAmount
+		}
+	} else if !order.QuoteAmount.IsPositive() || !order.Amount.IsZero() || order.Type != models.Market {
+		return ErrQuoteAmount
+	}
+	switch order.Type {
+	case models.Limit, models.StopLimit:
+		if !order.Price.IsPositive() && order.Peg == "" {
+			return ErrOrderPrice
+		}
+		switch order.TimeInForce {
+		case models.TimeInForceGTC, models.TimeInForceIOC, models.TimeInForceFOK, models.TimeInForceGTD:
+		default:
+			return ErrOrderTimeInForce
+		}
+	case models.Market, models.StopMarket:
+	default:
+		return ErrOrderType
+	}
+	if order.IsStop() && !order.IsTrailing() && !order.TriggerPrice.IsPositive() {
+		return ErrTriggerPrice
+	}
+	if !order.TrailingAmount.IsZero() || !order.TrailingPercent.IsZero() {
+		if !order.IsStop() || !order.TriggerPrice.IsZero() || order.TrailingAmount.IsNegative() || order.TrailingPercent.IsNegative() ||
+			order.TrailingAmount.IsPositive() == order.TrailingPercent.IsPositive() || order.TrailingPercent.GreaterThanOrEqual(decimal.NewFromInt(1)) {
+			return ErrTrailing
+		}
+	}
+	if order.ProtectPrice.IsNegative() || !order.ProtectPrice.IsZero() && order.Type != models.Market && order.Type != models.StopMarket {
+		return ErrProtectPrice
+	}
+	switch order.PostOnly {
+	case "":
+	case models.PostOnlyReject, models.PostOnlyReprice:
+		if order.Type != models.Limit && order.Type != models.StopLimit ||
+			order.TimeInForce != models.TimeInForceGTC && order.TimeInForce != models.TimeInForceGTD {
+			return ErrPostOnly
+		}
+	default:
+		return ErrPostOnly
+	}
+	if !order.DisplayAmount.IsZero() {
+		if !order.DisplayAmount.IsPositive() || order.DisplayAmount.GreaterThanOrEqual(order.Amount) ||
+			order.Type != models.Limit && order.Type != models.StopLimit ||
+			order.TimeInForce != models.TimeInForceGTC && order.TimeInForce != models.TimeInForceGTD {
+			return ErrDisplayAmount
+		}
+	}
+	if order.TimeInForce == models.TimeInForceGTD && order.ExpireTime <= utils.NowUnixMilli() ||
+		order.TimeInForce != models.TimeInForceGTD && order.ExpireTime != 0 {
+		return ErrExpireTime
+	}
+	switch order.Stp {
+	case "", models.StpCancelNewest, models.StpCancelOldest, models.StpCancelBoth, models.StpDecrementCancel:
+	default:
+		return ErrStp
+	}
+	if order.Invisible && (order.Type != models.Limit && order.Type != models.StopLimit ||
+		order.TimeInForce != models.TimeInForceGTC && order.TimeInForce != models.TimeInForceGTD || order.IsIceberg()) {
+		return ErrInvisible
+	}
+	switch order.Peg {
+	case "":
+		if !order.PegOffset.IsZero() || !order.PegLimit.IsZero() {
+			return ErrPeg
+		}
+	case models.PegPrimary, models.PegMidpoint:
+		if order.Type != models.Limit || order.TimeInForce != models.TimeInForceGTC && order.TimeInForce != models.TimeInForceGTD ||
+			!order.Price.IsZero() || order.PostOnly != "" || order.IsIceberg() || order.PegOffset.IsNegative() || order.PegLimit.IsNegative() {
+			return ErrPeg
+		}
+	default:
+		return ErrPeg
+	}
+	if !order.MinQty.IsZero() && (order.Type != models.Limit && order.Type != models.StopLimit ||
+		order.TimeInForce != models.TimeInForceGTC && order.TimeInForce != models.TimeInForceGTD && order.TimeInForce != models.TimeInForceIOC ||
+		!order.MinQty.IsPositive() || order.MinQty.GreaterThan(order.Amount)) {
+		return ErrMinQty
+	}
+	if order.AllOrNone && (order.Type != models.Limit && order.Type != models.StopLimit ||
+		order.TimeInForce != models.TimeInForceGTC && order.TimeInForce != models.TimeInForceGTD ||
+		order.IsIceberg() || order.Peg != "" || !order.MinQty.IsZero()) {
+		return ErrAllOrNone
+	}
+	if order.IsReduceOnly() && (order.IsQuote() || order.IsIceberg()) ||
+		order.ClosePosition && (order.Type != models.Market && order.Type != models.StopMarket || !order.Amount.IsZero()) {
+		return ErrReduceOnly
+	}
+	return nil
+}
+
+// add 挂单，只减仓订单先按持仓限制数量
+func (ob *Orderbook) add(order models.Order) ([]models.Trade, error) {
+	if !order.IsReduceOnly() {
+		return ob.place(order)
+	}
+	reduced := ob.restrict(&order)
+	if !order.Amount.IsPositive() {
+		return reduced, nil
+	}
+	trades, err := ob.place(order)
+	return append(reduced, trades...), err
+}
+
+// place 按交易阶段、订单类型和方向挂单
+func (ob *Orderbook) place(order models.Order) ([]models.Trade, error) {
+	if ob.phase == models.PhaseAuction {
+		return ob.addAuction(order)
+	}
+	if order.Peg != "" {
+		return ob.addPegged(order)
+	}
 	switch order.Side {
 	case models.Buy:
 		return ob.addBid(order)
 	case models.Sell:
 		return ob.addAsk(order)
 	}
-	return ErrOrderSide
+	return nil, ErrOrderSide
 }
 
 // addBid 挂bid
-func (ob *Orderbook) addBid(order models.Order) error {
+func (ob *Orderbook) addBid(order models.Order) ([]models.Trade, error) {
 	switch order.Type {
 	case models.Limit:
 		return ob.addBidLimit(order)
 	case models.Market:
 		return ob.addBidMarket(order)
+	case models.StopMarket, models.StopLimit:
+		return ob.addBidStop(order)
 	}
-	return ErrOrderType
+	return nil, ErrOrderType
 }
 
 // addAsk 挂ask
-func (ob *Orderbook) addAsk(order models.Order) error {
+func (ob *Orderbook) addAsk(order models.Order) ([]models.Trade, error) {
 	switch order.Type {
 	case models.Limit:
 		return ob.addAskLimit(order)
 	case models.Market:
 		return ob.addAskMarket(order)
+	case models.StopMarket, models.StopLimit:
+		return ob.addAskStop(order)
 	}
-	return ErrOrderType
+	return nil, ErrOrderType
 }
 
 // addBidLimit 挂bid限价单
-func (ob *Orderbook) addBidLimit(order models.Order) error {
+func (ob *Orderbook) addBidLimit(order models.Order) ([]models.Trade, error) {
 	switch order.TimeInForce {
 	case models.TimeInForceGTC:
 		return ob.addBidLimitGTC(order)
+	case models.TimeInForceGTD:
+		ob.schedule(&order)
+		return ob.addBidLimitGTC(order)
 	case models.TimeInForceIOC:
 		return ob.addBidLimitIOC(order)
 	case models.TimeInForceFOK:
 		return ob.addBidLimitFOK(order)
 	}
-	return ErrOrderTimeInForce
+	return nil, ErrOrderTimeInForce
 }
 
 // addAskLimit 挂ask限价单
-func (ob *Orderbook) addAskLimit(order models.Order) error {
+func (ob *Orderbook) addAskLimit(order models.Order) ([]models.Trade, error) {
 	switch order.TimeInForce {
 	case models.TimeInForceGTC:
 		return ob.addAskLimitGTC(order)
+	case models.TimeInForceGTD:
+		ob.schedule(&order)
+		return ob.addAskLimitGTC(order)
 	case models.TimeInForceIOC:
 		return ob.addAskLimitIOC(order)
 	case models.TimeInForceFOK:
 		return ob.addAskLimitFOK(order)
 	}
-	return ErrOrderTimeInForce
+	return nil, ErrOrderTimeInForce
 }
 
 // addBidMarket 挂bid市价单
-func (ob *Orderbook) addBidMarket(order models.Order) error {
-	trades := make([]models.Trade, 0)
-	first := &skiplist.SkipListNode{}
-
-	// order.Amount > 0
-	for ob.ask.First() != nil && order.Amount.GreaterThan(decimal.Zero) {
-		first = ob.ask.First()
-		if first.Value().GetAmount().GreaterThanOrEqual(order.Amount) { // ask.first.Amount >= order.Amount
-			trade := models.Trade{
-				Id:               utils.GenTradeId(),
-				Pair:             order.Pair,
-				MakerId:          first.Value().GetId(),
-				TakerId:          order.Id,
-				MakerUser:        first.Value().GetUserId(),
-				TakerUser:        order.UserId,
-				Price:            first.Score().String(),
-				Amount:           order.Amount.String(),
-				TakerOrderSide:   order.Side,
-				TakerOrderType:   order.Type,
-				TakerTimeInForce: order.TimeInForce,
-				Ts:               utils.NowUnixMilli(),
-			}
-			trades = append(trades, trade)
-
-			// 判断first剩余数量
-			amount := first.Value().GetAmount().Sub(order.Amount)
-			order.Amount = order.Amount.Sub(order.Amount)
-			if amount.GreaterThan(decimal.Zero) { // 剩余数量 > 0
-				first.Value().SetAmount(amount)
-			} else { // 剩余数量 <= 0
-				ob.ask.Delete(first.Score(), first.Value().GetId())
-			}
-		} else { // ask.first.Amount < order.Amount
-			trade := models.Trade{
-				Id:               utils.GenTradeId(),
-				Pair:             order.Pair,
-				MakerId:          first.Value().GetId(),
-				TakerId:          order.Id,
-				MakerUser:        first.Value().GetUserId(),
-				TakerUser:        order.UserId,
-				Price:            first.Score().String(),
-				Amount:           first.Value().GetAmount().String(),
-				TakerOrderSide:   order.Side,
-				TakerOrderType:   order.Type,
-				TakerTimeInForce: order.TimeInForce,
-				Ts:               utils.NowUnixMilli(),
-			}
-			trades = append(trades, trade)
-			order.Amount = order.Amount.Sub(first.Value().GetAmount())
-
-			// 删除first
-			ob.ask.Delete(first.Score(), first.Value().GetId())
-		}
+func (ob *Orderbook) addBidMarket(order models.Order) ([]models.Trade, error) {
+	crossed := ob.protect(&order, ob.ask)
+	if order.IsQuote() {
+		return ob.matchQuote(&order, ob.ask, ob.mAsk, crossed), nil
 	}
+	trades := ob.match(&order, ob.ask, ob.mAsk, crossed)
 
-	// 判断order是否完全成交
+	// 判断order是否完全成交，剩余部分撤销
 	if order.Amount.GreaterThan(decimal.Zero) {
-		trade := models.Trade{
-			Id:               utils.GenTradeId(),
-			Pair:             order.Pair,
-			MakerId:          order.Id,
-			TakerId:          order.Id,
-			MakerUser:        order.UserId,
-			TakerUser:        order.UserId,
-			Price:            order.Price.String(),
-			Amount:           order.Amount.String(),
-			TakerOrderSide:   order.Side,
-			TakerOrderType:   models.Cancel,
-			TakerTimeInForce: order.TimeInForce,
-			Ts:               utils.NowUnixMilli(),
-		}
-		trades = append(trades, trade)
-	}
-
-	if len(trades) > 0 {
-		ob.PushTrades(trades...)
+		trades = append(trades, cancelTrade(&order, slippage(&order, ob.ask, crossed)))
 	}
-	return nil
+	return trades, nil
 }
 
 // addAskMarket 挂ask市价单
-func (ob *Orderbook) addAskMarket(order models.Order) error {
-	trades := make([]models.Trade, 0)
-	first := &skiplist.SkipListNode{}
-
-	// order.Amount > 0
-	for ob.bid.First() != nil && order.Amount.GreaterThan(decimal.Zero) {
-		first = ob.bid.First()
-		if first.Value().GetAmount().GreaterThanOrEqual(order.Amount) { // bid.first.Amount >= order.Amount
-			trade := models.Trade{
-				Id:               utils.GenTradeId(),
-				Pair:             order.Pair,
-				MakerId:          first.Value().GetId(),
-				TakerId:          order.Id,
-				MakerUser:        first.Value().GetUserId(),
-				TakerUser:        order.UserId,
-				Price:            first.Score().String(),
-				Amount:           order.Amount.String(),
-				TakerOrderSide:   order.Side,
-				TakerOrderType:   order.Type,
-				TakerTimeInForce: order.TimeInForce,
-				Ts:               utils.NowUnixMilli(),
-			}
-			trades = append(trades, trade)
-
-			// 判断first剩余数量
-			amount := first.Value().GetAmount().Sub(order.Amount)
-			order.Amount = order.Amount.Sub(order.Amount)
-			if amount.GreaterThan(decimal.Zero) { // 剩余数量 > 0
-				first.Value().SetAmount(amount)
-			} else { // 剩余数量 <= 0
-				ob.bid.Delete(first.Score(), first.Value().GetId())
-			}
-		} else { // bid.first.Amount < order.Amount
-			trade := models.Trade{
-				Id:               utils.GenTradeId(),
-				Pair:             order.Pair,
-				MakerId:          first.Value().GetId(),
-				TakerId:          order.Id,
-				MakerUser:        first.Value().GetUserId(),
-				TakerUser:        order.UserId,
-				Price:            first.Score().String(),
-				Amount:           first.Value().GetAmount().String(),
-				TakerOrderSide:   order.Side,
-				TakerOrderType:   order.Type,
-				TakerTimeInForce: order.TimeInForce,
-				Ts:               utils.NowUnixMilli(),
-			}
-			trades = append(trades, trade)
-			order.Amount = order.Amount.Sub(first.Value().GetAmount())
-
-			// 删除first
-			ob.bid.Delete(first.Score(), first.Value().GetId())
-		}
+func (ob *Orderbook) addAskMarket(order models.Order) ([]models.Trade, error) {
+	crossed := ob.protect(&order, ob.bid)
+	if order.IsQuote() {
+		return ob.matchQuote(&order, ob.bid, ob.mBid, crossed), nil
 	}
+	trades := ob.match(&order, ob.bid, ob.mBid, crossed)
 
-	// 判断order是否完全成交
+	// 判断order是否完全成交，剩余部分撤销
 	if order.Amount.GreaterThan(decimal.Zero) {
-		trade := models.Trade{
-			Id:               utils.GenTradeId(),
-			Pair:             order.Pair,
-			MakerId:          order.Id,
-			TakerId:          order.Id,
-			MakerUser:        order.UserId,
-			TakerUser:        order.UserId,
-			Price:            order.Price.String(),
-			Amount:           order.Amount.String(),
-			TakerOrderSide:   order.Side,
-			TakerOrderType:   models.Cancel,
-			TakerTimeInForce: order.TimeInForce,
-			Ts:               utils.NowUnixMilli(),
-		}
-		trades = append(trades, trade)
-	}
-
-	if len(trades) > 0 {
-		ob.PushTrades(trades...)
+		trades = append(trades, cancelTrade(&order, slippage(&order, ob.bid, crossed)))
 	}
-	return nil
+	return trades, nil
 }
 
 // addBidLimitGTC 挂bid限价GTC订单
-func (ob *Orderbook) addBidLimitGTC(order models.Order) error {
-	trades := make([]models.Trade, 0)
-	first := &skiplist.SkipListNode{}
-
-	// ask.first.Score <= order.Price && order.Amount > 0
-	for ob.ask.First() != nil && ob.ask.First().Score().LessThanOrEqual(order.Price) && order.Amount.GreaterThan(decimal.Zero) {
-		first = ob.ask.First()
-		if first.Value().GetAmount().GreaterThanOrEqual(order.Amount) { // ask.first.Amount >= order.Amount
-			trade := models.Trade{
-				Id:               utils.GenTradeId(),
-				Pair:             order.Pair,
-				MakerId:          first.Value().GetId(),
-				TakerId:          order.Id,
-				MakerUser:        first.Value().GetUserId(),
-				TakerUser:        order.UserId,
-				Price:            first.Score().String(),
-				Amount:           order.Amount.String(),
-				TakerOrderSide:   order.Side,
-				TakerOrderType:   order.Type,
-				TakerTimeInForce: order.TimeInForce,
-				Ts:               utils.NowUnixMilli(),
-			}
-			trades = append(trades, trade)
-
-			// 判断first剩余数量
-			amount := first.Value().GetAmount().Sub(order.Amount)
-			order.Amount = order.Amount.Sub(order.Amount)
-			if amount.GreaterThan(decimal.Zero) { // 剩余数量 > 0
-				first.Value().SetAmount(amount)
-			} else { // 剩余数量 <= 0
-				ob.ask.Delete(first.Score(), first.Value().GetId())
-			}
-		} else { // ask.first.Amount < order.Amount
-			trade := models.Trade{
-				Id:               utils.GenTradeId(),
-				Pair:             order.Pair,
-				MakerId:          first.Value().GetId(),
-				TakerId:          order.Id,
-				MakerUser:        first.Value().GetUserId(),
-				TakerUser:        order.UserId,
-				Price:            first.Score().String(),
-				Amount:           first.Value().GetAmount().String(),
-				TakerOrderSide:   order.Side,
-				TakerOrderType:   order.Type,
-				TakerTimeInForce: order.TimeInForce,
-				Ts:               utils.NowUnixMilli(),
-			}
-			trades = append(trades, trade)
-			order.Amount = order.Amount.Sub(first.Value().GetAmount())
-
-			// 删除first
-			ob.ask.Delete(first.Score(), first.Value().GetId())
+func (ob *Orderbook) addBidLimitGTC(order models.Order) ([]models.Trade, error) {
+	// 只做maker订单会立即成交时，拒绝或调整到卖一价下方一个tick
+	if order.PostOnly != "" && ob.ask.First() != nil && ob.ask.First().Score().LessThanOrEqual(order.Price) {
+		best := ob.ask.First().Score()
+		price := best.Sub(ob.tick(best, order.Price))
+		if order.PostOnly == models.PostOnlyReject || !price.IsPositive() {
+			return []models.Trade{cancelTrade(&order, models.ReasonPostOnly)}, nil
 		}
+		order.Price = price
+	}
+
+	// 最小成交数量不足时整单撤销，全部成交订单不能全部成交时不撮合直接挂单
+	trades := make([]models.Trade, 0)
+	if ok, filled := ob.gate(&order, ob.ask, order.Price.GreaterThanOrEqual); ok {
+		// ask.first.Score <= order.Price
+		trades = ob.match(&order, ob.ask, ob.mAsk, order.Price.GreaterThanOrEqual)
+	} else if !order.AllOrNone && filled.IsPositive() {
+		return []models.Trade{cancelTrade(&order, models.ReasonMinQty)}, nil
 	}
 
 	// 判断order是否完全成交
 	if order.Amount.GreaterThan(decimal.Zero) {
+		split(&order)
 		ob.bid.Insert(order.Price, &order)
 		ob.mBid[order.Id] = order.Price
 	}
-
-	if len(trades) > 0 {
-		ob.PushTrades(trades...)
-	}
-	return nil
+	return trades, nil
 }
 
 // addAskLimitGTC 挂ask限价GTC订单
-func (ob *Orderbook) addAskLimitGTC(order models.Order) error {
-	trades := make([]models.Trade, 0)
-	first := &skiplist.SkipListNode{}
-
-	// bid.first.Score >= order.Price && order.Amount > 0
-	for ob.bid.First() != nil && ob.bid.First().Score().GreaterThanOrEqual(order.Price) && order.Amount.GreaterThan(decimal.Zero) {
-		first = ob.bid.First()
-		if first.Value().GetAmount().GreaterThanOrEqual(order.Amount) { // bid.first.Amount >= order.Amount
-			trade := models.Trade{
-				Id:               utils.GenTradeId(),
-				Pair:             order.Pair,
-				MakerId:          first.Value().GetId(),
-				TakerId:          order.Id,
-				MakerUser:        first.Value().GetUserId(),
-				TakerUser:        order.UserId,
-				Price:            first.Score().String(),
-				Amount:           order.Amount.String(),
-				TakerOrderSide:   order.Side,
-				TakerOrderType:   order.Type,
-				TakerTimeInForce: order.TimeInForce,
-				Ts:               utils.NowUnixMilli(),
-			}
-			trades = append(trades, trade)
-
-			// 判断first剩余数量
-			amount := first.Value().GetAmount().Sub(order.Amount)
-			order.Amount = order.Amount.Sub(order.Amount)
-			if amount.GreaterThan(decimal.Zero) { // 剩余数量 > 0
-				first.Value().SetAmount(amount)
-			} else { // 剩余数量 <= 0
-				ob.bid.Delete(first.Score(), first.Value().GetId())
-			}
-		} else { // bid.first.Amount < order.Amount
-			trade := models.Trade{
-				Id:               utils.GenTradeId(),
-				Pair:             order.Pair,
-				MakerId:          first.Value().GetId(),
-				TakerId:          order.Id,
-				MakerUser:        first.Value().GetUserId(),
-				TakerUser:        order.UserId,
-				Price:            first.Score().String(),
-				Amount:           first.Value().GetAmount().String(),
-				TakerOrderSide:   order.Side,
-				TakerOrderType:   order.Type,
-				TakerTimeInForce: order.TimeInForce,
-				Ts:               utils.NowUnixMilli(),
-			}
-			trades = append(trades, trade)
-			order.Amount = order.Amount.Sub(first.Value().GetAmount())
-
-			// 删除first
-			ob.bid.Delete(first.Score(), first.Value().GetId())
+func (ob *Orderbook) addAskLimitGTC(order models.Order) ([]models.Trade, error) {
+	// 只做maker订单会立即成交时，拒绝或调整到买一价上方一个tick
+	if order.PostOnly != "" && ob.bid.First() != nil && ob.bid.First().Score().GreaterThanOrEqual(order.Price) {
+		if order.PostOnly == models.PostOnlyReject {
+			return []models.Trade{cancelTrade(&order, models.ReasonPostOnly)}, nil
 		}
+		best := ob.bid.First().Score()
+		order.Price = best.Add(ob.tick(best, order.Price))
+	}
+
+	// 最小成交数量不足时整单撤销，全部成交订单不能全部成交时不撮合直接挂单
+	trades := make([]models.Trade, 0)
+	if ok, filled := ob.gate(&order, ob.bid, order.Price.LessThanOrEqual); ok {
+		// bid.first.Score >= order.Price
+		trades = ob.match(&order, ob.bid, ob.mBid, order.Price.LessThanOrEqual)
+	} else if !order.AllOrNone && filled.IsPositive() {
+		return []models.Trade{cancelTrade(&order, models.ReasonMinQty)}, nil
 	}
 
 	// 判断order是否完全成交
 	if order.Amount.GreaterThan(decimal.Zero) {
+		split(&order)
 		ob.ask.Insert(order.Price, &order)
 		ob.mAsk[order.Id] = order.Price
 	}
-
-	if len(trades) > 0 {
-		ob.PushTrades(trades...)
-	}
-	return nil
+	return trades, nil
 }
 
 // addBidLimitIOC 挂bid限价IOC订单
-func (ob *Orderbook) addBidLimitIOC(order models.Order) error {
-	trades := make([]models.Trade, 0)
-	first := &skiplist.SkipListNode{}
-
-	// ask.first.Score <= order.Price && order.Amount > 0
-	for ob.ask.First() != nil && ob.ask.First().Score().LessThanOrEqual(order.Price) && order.Amount.GreaterThan(decimal.Zero) {
-		first = ob.ask.First()
-		if first.Value().GetAmount().GreaterThanOrEqual(order.Amount) { // ask.first.Amount >= order.Amount
-			trade := models.Trade{
-				Id:               utils.GenTradeId(),
-				Pair:             order.Pair,
-				MakerId:          first.Value().GetId(),
-				TakerId:          order.Id,
-				MakerUser:        first.Value().GetUserId(),
-				TakerUser:        order.UserId,
-				Price:            first.Score().String(),
-				Amount:           order.Amount.String(),
-				TakerOrderSide:   order.Side,
-				TakerOrderType:   order.Type,
-				TakerTimeInForce: order.TimeInForce,
-				Ts:               utils.NowUnixMilli(),
-			}
-			trades = append(trades, trade)
-
-			// 判断first剩余数量
-			amount := first.Value().GetAmount().Sub(order.Amount)
-			order.Amount = order.Amount.Sub(order.Amount)
-			if amount.GreaterThan(decimal.Zero) { // 剩余数量 > 0
-				first.Value().SetAmount(amount)
-			} else { // 剩余数量 <= 0
-				ob.ask.Delete(first.Score(), first.Value().GetId())
-			}
-		} else { // ask.first.Amount < order.Amount
-			trade := models.Trade{
-				Id:               utils.GenTradeId(),
-				Pair:             order.Pair,
-				MakerId:          first.Value().GetId(),
-				TakerId:          order.Id,
-				MakerUser:        first.Value().GetUserId(),
-				TakerUser:        order.UserId,
-				Price:            first.Score().String(),
-				Amount:           first.Value().GetAmount().String(),
-				TakerOrderSide:   order.Side,
-				TakerOrderType:   order.Type,
-				TakerTimeInForce: order.TimeInForce,
-				Ts:               utils.NowUnixMilli(),
-			}
-			trades = append(trades, trade)
-			order.Amount = order.Amount.Sub(first.Value().GetAmount())
-
-			// 删除first
-			ob.ask.Delete(first.Score(), first.Value().GetId())
-		}
+func (ob *Orderbook) addBidLimitIOC(order models.Order) ([]models.Trade, error) {
+	// 判断能否成交最小数量
+	if ok, _ := ob.gate(&order, ob.ask, order.Price.GreaterThanOrEqual); !ok {
+		return []models.Trade{cancelTrade(&order, models.ReasonMinQty)}, nil
 	}
 
+	// ask.first.Score <= order.Price
+	trades := ob.match(&order, ob.ask, ob.mAsk, order.Price.GreaterThanOrEqual)
+
 	// 判断order是否完全成交
 	if order.Amount.GreaterThan(decimal.Zero) {
-		trade := models.Trade{
-			Id:               utils.GenTradeId(),
-			Pair:             order.Pair,
-			MakerId:          order.Id,
-			TakerId:          order.Id,
-			MakerUser:        order.UserId,
-			TakerUser:        order.UserId,
-			Price:            order.Price.String(),
-			Amount:           order.Amount.String(),
-			TakerOrderSide:   order.Side,
-			TakerOrderType:   models.Cancel,
-			TakerTimeInForce: order.TimeInForce,
-			Ts:               utils.NowUnixMilli(),
-		}
-		trades = append(trades, trade)
-	}
-
-	if len(trades) > 0 {
-		ob.PushTrades(trades...)
+		trades = append(trades, cancelTrade(&order, ""))
 	}
-	return nil
+	return trades, nil
 }
 
 // addAskLimitIOC 挂ask限价IOC订单
-func (ob *Orderbook) addAskLimitIOC(order models.Order) error {
-	trades := make([]models.Trade, 0)
-	first := &skiplist.SkipListNode{}
-
-	// bid.first.Score >= order.Price && order.Amount > 0
-	for ob.bid.First() != nil && ob.bid.First().Score().GreaterThanOrEqual(order.Price) && order.Amount.GreaterThan(decimal.Zero) {
-		first = ob.bid.First()
-		if first.Value().GetAmount().GreaterThanOrEqual(order.Amount) { // bid.first.Amount >= order.Amount
-			trade := models.Trade{
-				Id:               utils.GenTradeId(),
-				Pair:             order.Pair,
-				MakerId:          first.Value().GetId(),
-				TakerId:          order.Id,
-				MakerUser:        first.Value().GetUserId(),
-				TakerUser:        order.UserId,
-				Price:            first.Score().String(),
-				Amount:           order.Amount.String(),
-				TakerOrderSide:   order.Side,
-				TakerOrderType:   order.Type,
-				TakerTimeInForce: order.TimeInForce,
-				Ts:               utils.NowUnixMilli(),
-			}
-			trades = append(trades, trade)
-
-			// 判断first剩余数量
-			amount := first.Value().GetAmount().Sub(order.Amount)
-			order.Amount = order.Amount.Sub(order.Amount)
-			if amount.GreaterThan(decimal.Zero) { // 剩余数量 > 0
-				first.Value().SetAmount(amount)
-			} else { // 剩余数量 <= 0
-				ob.bid.Delete(first.Score(), first.Value().GetId())
-			}
-		} else { // bid.first.Amount < order.Amount
-			trade := models.Trade{
-				Id:               utils.GenTradeId(),
-				Pair:             order.Pair,
-				MakerId:          first.Value().GetId(),
-				TakerId:          order.Id,
-				MakerUser:        first.Value().GetUserId(),
-				TakerUser:        order.UserId,
-				Price:            first.Score().String(),
-				Amount:           first.Value().GetAmount().String(),
-				TakerOrderSide:   order.Side,
-				TakerOrderType:   order.Type,
-				TakerTimeInForce: order.TimeInForce,
-				Ts:               utils.NowUnixMilli(),
-			}
-			trades = append(trades, trade)
-			order.Amount = order.Amount.Sub(first.Value().GetAmount())
-
-			// 删除first
-			ob.bid.Delete(first.Score(), first.Value().GetId())
-		}
+func (ob *Orderbook) addAskLimitIOC(order models.Order) ([]models.Trade, error) {
+	// 判断能否成交最小数量
+	if ok, _ := ob.gate(&order, ob.bid, order.Price.LessThanOrEqual); !ok {
+		return []models.Trade{cancelTrade(&order, models.ReasonMinQty)}, nil
 	}
 
+	// bid.first.Score >= order.Price
+	trades := ob.match(&order, ob.bid, ob.mBid, order.Price.LessThanOrEqual)
+
 	// 判断order是否完全成交
 	if order.Amount.GreaterThan(decimal.Zero) {
-		trade := models.Trade{
-			Id:               utils.GenTradeId(),
-			Pair:             order.Pair,
-			MakerId:          order.Id,
-			TakerId:          order.Id,
-			MakerUser:        order.UserId,
-			TakerUser:        order.UserId,
-			Price:            order.Price.String(),
-			Amount:           order.Amount.String(),
-			TakerOrderSide:   order.Side,
-			TakerOrderType:   models.Cancel,
-			TakerTimeInForce: order.TimeInForce,
-			Ts:               utils.NowUnixMilli(),
-		}
-		trades = append(trades, trade)
-	}
-
-	if len(trades) > 0 {
-		ob.PushTrades(trades...)
+		trades = append(trades, cancelTrade(&order, ""))
 	}
-	return nil
+	return trades, nil
 }
 
 // addBidLimitFOK 挂bid限价FOK订单
-func (ob *Orderbook) addBidLimitFOK(order models.Order) error {
-	trades := make([]models.Trade, 0)
-	first := &skiplist.SkipListNode{}
-
+func (ob *Orderbook) addBidLimitFOK(order models.Order) ([]models.Trade, error) {
 	// 判断能否全部成交
-	amount := order.Amount
-	first = ob.ask.First()
-	for first != nil && first.Score().LessThanOrEqual(order.Price) && amount.GreaterThan(decimal.Zero) {
-		if first.Value().GetAmount().GreaterThanOrEqual(order.Amount) { // ask.first.Amount >= order.Amount
-			amount = amount.Sub(amount)
-		} else {
-			amount = amount.Sub(first.Value().GetAmount())
-			first = first.Next(0)
-		}
-	}
-	if amount.GreaterThan(decimal.Zero) { // 剩余数量 > 0, 撤销
-		trade := models.Trade{
-			Id:               utils.GenTradeId(),
-			Pair:             order.Pair,
-			MakerId:          order.Id,
-			TakerId:          order.Id,
-			MakerUser:        order.UserId,
-			TakerUser:        order.UserId,
-			Price:            order.Price.String(),
-			Amount:           order.Amount.String(),
-			TakerOrderSide:   order.Side,
-			TakerOrderType:   models.Cancel,
-			TakerTimeInForce: order.TimeInForce,
-			Ts:               utils.NowUnixMilli(),
-		}
-		trades = append(trades, trade)
-		ob.PushTrades(trades...)
-		return nil
+	if ob.executable(&order, ob.ask, order.Price.GreaterThanOrEqual).LessThan(order.Amount) {
+		return []models.Trade{cancelTrade(&order, "")}, nil
 	}
 
-	// ask.first.Score <= order.Price && order.Amount > 0
-	for ob.ask.First() != nil && ob.ask.First().Score().LessThanOrEqual(order.Price) && order.Amount.GreaterThan(decimal.Zero) {
-		first = ob.ask.First()
-		if first.Value().GetAmount().GreaterThanOrEqual(order.Amount) { // ask.first.Amount >= order.Amount
-			trade := models.Trade{
-				Id:               utils.GenTradeId(),
-				Pair:             order.Pair,
-				MakerId:          first.Value().GetId(),
-				TakerId:          order.Id,
-				MakerUser:        first.Value().GetUserId(),
-				TakerUser:        order.UserId,
-				Price:            first.Score().String(),
-				Amount:           order.Amount.String(),
-				TakerOrderSide:   order.Side,
-				TakerOrderType:   order.Type,
-				TakerTimeInForce: order.TimeInForce,
-				Ts:               utils.NowUnixMilli(),
-			}
-			trades = append(trades, trade)
-
-			// 判断first剩余数量
-			amount := first.Value().GetAmount().Sub(order.Amount)
-			order.Amount = order.Amount.Sub(order.Amount)
-			if amount.GreaterThan(decimal.Zero) { // 剩余数量 > 0
-				first.Value().SetAmount(amount)
-			} else { // 剩余数量 <= 0
-				ob.ask.Delete(first.Score(), first.Value().GetId())
-			}
-		} else { // ask.first.Amount < order.Amount
-			trade := models.Trade{
-				Id:               utils.GenTradeId(),
-				Pair:             order.Pair,
-				MakerId:          first.Value().GetId(),
-				TakerId:          order.Id,
-				MakerUser:        first.Value().GetUserId(),
-				TakerUser:        order.UserId,
-				Price:            first.Score().String(),
-				Amount:           first.Value().GetAmount().String(),
-				TakerOrderSide:   order.Side,
-				TakerOrderType:   order.Type,
-				TakerTimeInForce: order.TimeInForce,
-				Ts:               utils.NowUnixMilli(),
-			}
-			trades = append(trades, trade)
-			order.Amount = order.Amount.Sub(first.Value().GetAmount())
+	// ask.first.Score <= order.Price
+	trades := ob.match(&order, ob.ask, ob.mAsk, order.Price.GreaterThanOrEqual)
 
-			// 删除first
-			ob.ask.Delete(first.Score(), first.Value().GetId())
-		}
+	// 判断order是否完全成交
+	if order.Amount.GreaterThan(decimal.Zero) {
+		trades = append(trades, cancelTrade(&order, ""))
+	}
+	return trades, nil
+}
+
+// addAskLimitFOK 挂ask限价FOK订单
+func (ob *Orderbook) addAskLimitFOK(order models.Order) ([]models.Trade, error) {
+	// 判断能否全部成交
+	if ob.executable(&order, ob.bid, order.Price.LessThanOrEqual).LessThan(order.Amount) {
+		return []models.Trade{cancelTrade(&order, "")}, nil
 	}
 
+	// bid.first.Score >= order.Price
+	trades := ob.match(&order, ob.bid, ob.mBid, order.Price.LessThanOrEqual)
+
 	// 判断order是否完全成交
 	if order.Amount.GreaterThan(decimal.Zero) {
-		trade := models.Trade{
-			Id:               utils.GenTradeId(),
-			Pair:             order.Pair,
-			MakerId:          order.Id,
-			TakerId:          order.Id,
-			MakerUser:        order.UserId,
-			TakerUser:        order.UserId,
-			Price:            order.Price.String(),
-			Amount:           order.Amount.String(),
-			TakerOrderSide:   order.Side,
-			TakerOrderType:   models.Cancel,
-			TakerTimeInForce: order.TimeInForce,
-			Ts:               utils.NowUnixMilli(),
-		}
-		trades = append(trades, trade)
-	}
-
-	if len(trades) > 0 {
-		ob.PushTrades(trades...)
+		trades = append(trades, cancelTrade(&order, ""))
 	}
-	return nil
+	return trades, nil
 }
 
-// addAskLimitFOK 挂ask限价FOK订单
-func (ob *Orderbook) addAskLimitFOK(order models.Order) error {
+// match taker订单按价格优先、时间优先与对手盘撮合，crossed判断对手价是否可以成交。
+// 成交后order.Amount为剩余数量，完全成交的maker从盘口删除
+func (ob *Orderbook) match(order *models.Order, makers book, index map[string]decimal.Decimal, crossed func(price decimal.Decimal) bool) []models.Trade {
 	trades := make([]models.Trade, 0)
-	first := &skiplist.SkipListNode{}
 
-	// 判断能否全部成交
-	amount := order.Amount
-	first = ob.bid.First()
-	for first != nil && first.Score().GreaterThanOrEqual(order.Price) && amount.GreaterThan(decimal.Zero) {
-		if first.Value().GetAmount().GreaterThanOrEqual(order.Amount) { // bid.first.Amount >= order.Amount
-			amount = amount.Sub(amount)
-		} else {
-			amount = amount.Sub(first.Value().GetAmount())
-			first = first.Next(0)
-		}
-	}
-	if amount.GreaterThan(decimal.Zero) { // 剩余数量 > 0, 撤销
-		trade := models.Trade{
-			Id:               utils.GenTradeId(),
-			Pair:             order.Pair,
-			MakerId:          order.Id,
-			TakerId:          order.Id,
-			MakerUser:        order.UserId,
-			TakerUser:        order.UserId,
-			Price:            order.Price.String(),
-			Amount:           order.Amount.String(),
-			TakerOrderSide:   order.Side,
-			TakerOrderType:   models.Cancel,
-			TakerTimeInForce: order.TimeInForce,
-			Ts:               utils.NowUnixMilli(),
-		}
-		trades = append(trades, trade)
-		ob.PushTrades(trades...)
-		return nil
-	}
+	// 连续撮合 && order.Amount > 0 && 存在可成交的maker
+	for ob.phase == models.PhaseContinuous && order.Amount.GreaterThan(decimal.Zero) {
+		first := eligible(makers, order.Amount, crossed)
+		if first == nil {
+			break
+		}
+		maker := first.Value()
 
-	// bid.first.Score >= order.Price && order.Amount > 0
-	for ob.bid.First() != nil && ob.bid.First().Score().GreaterThanOrEqual(order.Price) && order.Amount.GreaterThan(decimal.Zero) {
-		first = ob.bid.First()
-		if first.Value().GetAmount().GreaterThanOrEqual(order.Amount) { // bid.first.Amount >= order.Amount
-			trade := models.Trade{
-				Id:               utils.GenTradeId(),
-				Pair:             order.Pair,
-				MakerId:          first.Value().GetId(),
-				TakerId:          order.Id,
-				MakerUser:        first.Value().GetUserId(),
-				TakerUser:        order.UserId,
-				Price:            first.Score().String(),
-				Amount:           order.Amount.String(),
-				TakerOrderSide:   order.Side,
-				TakerOrderType:   order.Type,
-				TakerTimeInForce: order.TimeInForce,
-				Ts:               utils.NowUnixMilli(),
-			}
-			trades = append(trades, trade)
-
-			// 判断first剩余数量
-			amount := first.Value().GetAmount().Sub(order.Amount)
-			order.Amount = order.Amount.Sub(order.Amount)
-			if amount.GreaterThan(decimal.Zero) { // 剩余数量 > 0
-				first.Value().SetAmount(amount)
-			} else { // 剩余数量 <= 0
-				ob.bid.Delete(first.Score(), first.Value().GetId())
-			}
-		} else { // bid.first.Amount < order.Amount
-			trade := models.Trade{
-				Id:               utils.GenTradeId(),
-				Pair:             order.Pair,
-				MakerId:          first.Value().GetId(),
-				TakerId:          order.Id,
-				MakerUser:        first.Value().GetUserId(),
-				TakerUser:        order.UserId,
-				Price:            first.Score().String(),
-				Amount:           first.Value().GetAmount().String(),
-				TakerOrderSide:   order.Side,
-				TakerOrderType:   order.Type,
-				TakerTimeInForce: order.TimeInForce,
-				Ts:               utils.NowUnixMilli(),
+		// 成交价触发熔断，停止撮合
+		if ob.breached(first.Score()) {
+			trades = append(trades, ob.trip(order)...)
+			break
+		}
+
+		// 按比例分配的交易对，taker不能吃完当前价格档位时在档位内按比例分配
+		if ob.spec.Allocation == conf.AllocationProRata || ob.spec.Allocation == conf.AllocationHybrid {
+			if result := ob.allocate(order, makers, index, first); len(result) > 0 {
+				trades = append(trades, result...)
+				continue
 			}
-			trades = append(trades, trade)
-			order.Amount = order.Amount.Sub(first.Value().GetAmount())
+		}
 
-			// 删除first
-			ob.bid.Delete(first.Score(), first.Value().GetId())
+		// 自成交保护
+		if order.Stp != "" && maker.GetUserId() == order.UserId {
+			trades = append(trades, ob.selfTrade(order, first, makers, index)...)
+			continue
 		}
+
+		trades = append(trades, ob.fill(order, makers, index, first, decimal.Min(maker.GetAmount(), order.Amount)))
 	}
+	return trades
+}
 
-	// 判断order是否完全成交
-	if order.Amount.GreaterThan(decimal.Zero) {
-		trade := models.Trade{
-			Id:               utils.GenTradeId(),
-			Pair:             order.Pair,
-			MakerId:          order.Id,
-			TakerId:          order.Id,
-			MakerUser:        order.UserId,
-			TakerUser:        order.UserId,
-			Price:            order.Price.String(),
-			Amount:           order.Amount.String(),
-			TakerOrderSide:   order.Side,
-			TakerOrderType:   models.Cancel,
-			TakerTimeInForce: order.TimeInForce,
-			Ts:               utils.NowUnixMilli(),
-		}
-		trades = append(trades, trade)
-	}
-
-	if len(trades) > 0 {
-		ob.PushTrades(trades...)
+// fill taker与maker按maker的价格成交amount数量，maker完全成交时从盘口删除
+func (ob *Orderbook) fill(order *models.Order, makers book, index map[string]decimal.Decimal, node *skiplist.SkipListNode, amount decimal.Decimal) models.Trade {
+	maker := node.Value()
+	trade := models.Trade{
+		Id:               utils.GenTradeId(),
+		Pair:             order.Pair,
+		MakerId:          maker.GetId(),
+		TakerId:          order.Id,
+		MakerUser:        maker.GetUserId(),
+		TakerUser:        order.UserId,
+		Price:            node.Score().String(),
+		Amount:           amount.String(),
+		TakerOrderSide:   order.Side,
+		TakerOrderType:   order.Type,
+		TakerTimeInForce: order.TimeInForce,
+		Ts:               utils.NowUnixMilli(),
+	}
+	order.Amount = order.Amount.Sub(amount)
+	ob.record(node.Score())
+
+	// 判断maker剩余数量
+	if maker.GetAmount().GreaterThan(amount) { // 剩余数量 > 0
+		maker.SetAmount(maker.GetAmount().Sub(amount))
+	} else if !replenish(makers, node) { // 剩余数量 <= 0, 冰山单补充显示数量，否则删除maker
+		remove(makers, index, node)
+	}
+	return trade
+}
+
+// eligible 返回第一个可以与taker成交的maker，跳过剩余数量超过amount、不能一次全部成交的全部成交订单，
+// 被跳过的订单和其他订单的价格优先、时间优先顺序不变
+func eligible(makers book, amount decimal.Decimal, crossed func(price decimal.Decimal) bool) *skiplist.SkipListNode {
+	for p := makers.First(); p != nil && crossed(p.Score()); p = p.Next(0) {
+		if maker := p.Value().(*models.Order); maker.AllOrNone && maker.Amount.GreaterThan(amount) {
+			continue
+		}
+		return p
 	}
 	return nil
 }
 
+// gate 判断taker订单能否满足最小成交数量和全部成交条件，filled为对手盘可以立即成交的数量，不限制时为0
+func (ob *Orderbook) gate(order *models.Order, makers book, crossed func(price decimal.Decimal) bool) (ok bool, filled decimal.Decimal) {
+	min := order.MinFill()
+	if !min.IsPositive() {
+		return true, decimal.Zero
+	}
+	filled = ob.executable(order, makers, crossed)
+	return filled.GreaterThanOrEqual(min), filled
+}
+
+// executable 对手盘可以立即成交taker订单的数量
+func (ob *Orderbook) executable(order *models.Order, makers book, crossed func(price decimal.Decimal) bool) decimal.Decimal {
+	amount := order.Amount
+	low, high := ob.window()
+	for p := makers.First(); p != nil && crossed(p.Score()) && amount.GreaterThan(decimal.Zero); p = p.Next(0) {
+		maker := p.Value().(*models.Order)
+		if maker.AllOrNone && maker.Amount.GreaterThan(amount) { // 不能全部成交的maker被跳过
+			continue
+		}
+		if ob.enabled() { // 触发熔断的价格不能成交，本单之前的成交也计入熔断窗口
+			if ob.exceeds(p.Score(), low, high) {
+				break
+			}
+			if low.IsZero() || p.Score().LessThan(low) {
+				low = p.Score()
+			}
+			high = decimal.Max(high, p.Score())
+		}
+		if order.Stp != "" && maker.UserId == order.UserId {
+			if order.Stp == models.StpCancelOldest { // 自成交的maker被撤销，不参与成交
+				continue
+			}
+			break
+		}
+		amount = amount.Sub(decimal.Min(maker.TotalAmount(), amount))
+	}
+	return order.Amount.Sub(amount)
+}
+
+// remove 从盘口删除maker订单
+func remove(makers book, index map[string]decimal.Decimal, node *skiplist.SkipListNode) {
+	makers.Delete(node.Score(), node.Value().GetId())
+	delete(index, node.Value().GetId())
+}
+
+// anyPrice 市价单可以与任意价格成交
+func anyPrice(decimal.Decimal) bool {
+	return true
+}
+
 // cancel 撤单
 func (ob *Orderbook) cancel(id string) error {
 	ob.status.Add(1)
@@ -846,6 +1089,10 @@ func (ob *Orderbook) cancel(id string) error {
 		return ob.cancelBid(score, id)
 	} else if score, ok := ob.mAsk[id]; ok {
 		return ob.cancelAsk(score, id)
+	} else if score, ok := ob.mStopBid[id]; ok {
+		return ob.cancelStopBid(score, id)
+	} else if score, ok := ob.mStopAsk[id]; ok {
+		return ob.cancelStopAsk(score, id)
 	} else {
 		return ErrOrderId
 	}
@@ -872,7 +1119,7 @@ func (ob *Orderbook) cancelBid(score decimal.Decimal, id string) error {
 		MakerUser:        order.UserId,
 		TakerUser:        order.UserId,
 		Price:            order.Price.String(),
-		Amount:           order.Amount.String(),
+		Amount:           order.TotalAmount().String(),
 		TakerOrderSide:   order.Side,
 		TakerOrderType:   models.Cancel,
 		TakerTimeInForce: order.TimeInForce,
@@ -904,7 +1151,7 @@ func (ob *Orderbook) cancelAsk(score decimal.Decimal, id string) error {
 		MakerUser:        order.UserId,
 		TakerUser:        order.UserId,
 		Price:            order.Price.String(),
-		Amount:           order.Amount.String(),
+		Amount:           order.TotalAmount().String(),
 		TakerOrderSide:   order.Side,
 		TakerOrderType:   models.Cancel,
 		TakerTimeInForce: order.TimeInForce,
@@ -915,7 +1162,96 @@ func (ob *Orderbook) cancelAsk(score decimal.Decimal, id string) error {
 	return nil
 }
 
+// depth 聚合买卖盘深度
+func (ob *Orderbook) depth(levels int) *models.Depth {
+	return &models.Depth{
+		Pair: ob.pair,
+		Bids: depthLevels(ob.bid.First(), levels),
+		Asks: depthLevels(ob.ask.First(), levels),
+		Ts:   utils.NowUnixMilli(),
+	}
+}
+
+// depthLevels 从first开始按价格聚合档位，levels<=0时聚合全部档位，隐藏单不计入深度
+func depthLevels(first *skiplist.SkipListNode, levels int) []models.DepthLevel {
+	result := make([]models.DepthLevel, 0)
+	for p := first; p != nil; p = p.Next(0) {
+		if p.Value().(*models.Order).Invisible {
+			continue
+		}
+		n := len(result)
+		if n > 0 && result[n-1].Price.Equal(p.Score()) { // 同一价格，合并到当前档位
+			result[n-1].Amount = result[n-1].Amount.Add(p.Value().GetAmount())
+			result[n-1].Count++
+			continue
+		}
+		if levels > 0 && n >= levels {
+			break
+		}
+		result = append(result, models.DepthLevel{
+			Price:  p.Score(),
+			Amount: p.Value().GetAmount(),
+			Count:  1,
+		})
+	}
+	return result
+}
+
+// tick 价格的最小变动单位，交易对未配置PriceTick时按价格精度计算
+func (ob *Orderbook) tick(prices ...decimal.Decimal) decimal.Decimal {
+	if ob.spec.PriceTick.IsPositive() {
+		return ob.spec.PriceTick
+	}
+	return minTick(prices...)
+}
+
+// minTick 价格精度对应的最小变动单位
+func minTick(prices ...decimal.Decimal) decimal.Decimal {
+	exp := int32(0)
+	for _, p := range prices {
+		if p.Exponent() < exp {
+			exp = p.Exponent()
+		}
+	}
+	return decimal.New(1, exp)
+}
+
+// cancelTrade 订单撤销记录，以成交单的形式推送
+func cancelTrade(order *models.Order, reason string) models.Trade {
+	return eventTrade(order, models.Cancel, order.TotalAmount(), reason)
+}
+
+// eventTrade 订单事件记录(撤销、触发等)，以成交单的形式推送，TakerOrderType为事件类型
+func eventTrade(order *models.Order, typ string, amount decimal.Decimal, reason string) models.Trade {
+	return models.Trade{
+		Id:               utils.GenTradeId(),
+		Pair:             order.Pair,
+		MakerId:          order.Id,
+		TakerId:          order.Id,
+		MakerUser:        order.UserId,
+		TakerUser:        order.UserId,
+		Price:            order.Price.String(),
+		Amount:           amount.String(),
+		TakerOrderSide:   order.Side,
+		TakerOrderType:   typ,
+		TakerTimeInForce: order.TimeInForce,
+		Ts:               utils.NowUnixMilli(),
+		Reason:           reason,
+	}
+}
+
 // PushTrades 推送成交单
 func (ob *Orderbook) PushTrades(trades ...models.Trade) {
+	if ob.replaying { // 回放时不重复推送，崩溃前最后一条指令的成交单可能未推送，需下游对账
+		return
+	}
 	ob.mq.PushTrade(trades...)
 }
+
+// PushEvents 推送盘口事件
+func (ob *Orderbook) PushEvents(events ...models.Event) {
+	if ob.replaying {
+		return
+	}
+	ob.mq.PushEvent(events...)
+}
@@ -0,0 +1,130 @@
+package match
+
+import (
+	"github.com/shopspring/decimal"
+	"lightning-engine/models"
+	"sort"
+)
+
+// addPegged 挂挂钩单，按参考价计算价格后直接挂在盘口，没有参考价时拒绝。
+// 挂钩单只做maker，价格会与对手盘成交时调整到对手价一个tick之外
+func (ob *Orderbook) addPegged(order models.Order) ([]models.Trade, error) {
+	price := ob.pegPrice(&order)
+	if price.IsZero() {
+		return []models.Trade{cancelTrade(&order, models.ReasonPeg)}, nil
+	}
+	order.Price = price
+	ob.schedule(&order)
+	if order.Side == models.Buy {
+		ob.bid.Insert(order.Price, &order)
+		ob.mBid[order.Id] = order.Price
+	} else {
+		ob.ask.Insert(order.Price, &order)
+		ob.mAsk[order.Id] = order.Price
+	}
+	ob.pegs[order.Id] = struct{}{}
+	return nil, nil
+}
+
+// reference 不含挂钩单和隐藏单的买一价和卖一价，挂钩单不作为其他挂钩单的参考价，一侧没有订单时为0
+func (ob *Orderbook) reference() (decimal.Decimal, decimal.Decimal) {
+	var bid, ask decimal.Decimal
+	for p := ob.bid.First(); p != nil; p = p.Next(0) {
+		if order := p.Value().(*models.Order); order.Peg == "" && !order.Invisible {
+			bid = p.Score()
+			break
+		}
+	}
+	for p := ob.ask.First(); p != nil; p = p.Next(0) {
+		if order := p.Value().(*models.Order); order.Peg == "" && !order.Invisible {
+			ask = p.Score()
+			break
+		}
+	}
+	return bid, ask
+}
+
+// pegPrice 挂钩单的价格：参考价按偏移调整后，买单向下、卖单向上取整到tick，再按价格限制和对手价调整。
+// 没有参考价或价格不为正时返回0
+func (ob *Orderbook) pegPrice(order *models.Order) decimal.Decimal {
+	bid, ask := ob.reference()
+	var ref decimal.Decimal
+	switch {
+	case order.Peg == models.PegPrimary && order.Side == models.Buy:
+		ref = bid
+	case order.Peg == models.PegPrimary && order.Side == models.Sell:
+		ref = ask
+	case order.Peg == models.PegMidpoint && !bid.IsZero() && !ask.IsZero():
+		ref = bid.Add(ask).Div(decimal.NewFromInt(2))
+	}
+	if ref.IsZero() {
+		return decimal.Zero
+	}
+	step := ob.tick(bid, ask, order.PegOffset, order.PegLimit)
+	var price decimal.Decimal
+	if order.Side == models.Buy {
+		price = ref.Sub(order.PegOffset).Div(step).Floor().Mul(step)
+		if order.PegLimit.IsPositive() && price.GreaterThan(order.PegLimit) {
+			price = order.PegLimit
+		}
+		if best := ob.ask.First(); best != nil && best.Score().LessThanOrEqual(price) {
+			price = best.Score().Sub(ob.tick(best.Score(), price))
+		}
+	} else {
+		price = ref.Add(order.PegOffset).Div(step).Ceil().Mul(step)
+		if order.PegLimit.IsPositive() && price.LessThan(order.PegLimit) {
+			price = order.PegLimit
+		}
+		if best := ob.bid.First(); best != nil && best.Score().GreaterThanOrEqual(price) {
+			price = best.Score().Add(ob.tick(best.Score(), price))
+		}
+	}
+	if !price.IsPositive() {
+		return decimal.Zero
+	}
+	return price
+}
+
+// repeg 最优价变化后重新计算挂钩单的价格，返回改价记录。
+// 价格变化的挂钩单按新价格重新排在该价格的末尾，失去时间优先级；价格不变时保持原有优先级。
+// 挂钩单按订单id顺序依次重新定价，只在连续撮合阶段重新定价，没有参考价时保持原价格
+func (ob *Orderbook) repeg() []models.Trade {
+	if len(ob.pegs) == 0 || ob.phase != models.PhaseContinuous {
+		return nil
+	}
+	ids := make([]string, 0, len(ob.pegs))
+	for id := range ob.pegs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	trades := make([]models.Trade, 0)
+	for _, id := range ids {
+		var orders book = ob.bid
+		index := ob.mBid
+		score, ok := index[id]
+		if !ok {
+			orders, index = ob.ask, ob.mAsk
+			score, ok = index[id]
+		}
+		if !ok { // 已成交或撤销
+			delete(ob.pegs, id)
+			continue
+		}
+		node, _ := orders.Find(score, id)
+		if node == nil {
+			delete(ob.pegs, id)
+			continue
+		}
+		order := node.Value().(*models.Order)
+		price := ob.pegPrice(order)
+		if price.IsZero() || price.Equal(score) {
+			continue
+		}
+		orders.Delete(score, id)
+		order.Price = price
+		orders.Insert(price, order)
+		index[id] = price
+		trades = append(trades, eventTrade(order, models.Amend, order.TotalAmount(), models.ReasonPeg))
+	}
+	return trades
+}
@@ -1,43 +1,252 @@
 package match
 
 import (
+	"github.com/shopspring/decimal"
+	"lightning-engine/internal/conf"
 	"lightning-engine/internal/status"
 	"lightning-engine/models"
 	"lightning-engine/mq"
+	"lightning-engine/position"
+	"log"
+	"sync"
 )
 
 // MatchPool 撮合池
 type MatchPool struct {
+	mu   sync.RWMutex // 保护pool，运行时可以增加和下架交易对
 	pool map[string]*Orderbook
+
+	adding map[string]struct{} // 正在恢复盘口的交易对，恢复时不持有锁，避免阻塞其他交易对
+
+	status   *status.Status
+	mq       mq.IMQ
+	position position.IPosition // 持仓接口，可以为nil，为nil时不接受只减仓订单
+	data     *conf.Data
 }
 
-func NewMatchPool(status *status.Status, pairs []string, mq mq.IMQ) (*MatchPool, error) {
-	mp := MatchPool{}
+func NewMatchPool(status *status.Status, pairs []conf.Pair, mq mq.IMQ, position position.IPosition, data *conf.Data) (*MatchPool, error) {
+	mp := MatchPool{status: status, mq: mq, position: position, data: data}
 	mp.pool = make(map[string]*Orderbook)
+	mp.adding = make(map[string]struct{})
 	for _, p := range pairs {
-		ob, err := NewOrderbook(status, p, mq)
-		if err != nil {
+		if err := mp.AddPair(p); err != nil {
 			return nil, err
 		}
-		status.Add(1)
-		go ob.Begin()
-		mp.pool[p] = ob
 	}
 	return &mp, nil
 }
 
+// AddPair 增加交易对并开始撮合，配置了数据目录时先从快照和日志恢复盘口。
+// 恢复盘口时不持有锁，同一交易对正在增加时返回ErrPairExists
+func (mp *MatchPool) AddPair(p conf.Pair) error {
+	if err := checkPair(p); err != nil {
+		return err
+	}
+	mp.mu.Lock()
+	_, exists := mp.pool[p.Symbol]
+	_, adding := mp.adding[p.Symbol]
+	if !exists && !adding {
+		mp.adding[p.Symbol] = struct{}{}
+	}
+	mp.mu.Unlock()
+	if exists || adding {
+		return ErrPairExists
+	}
+
+	ob, err := mp.open(p)
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	delete(mp.adding, p.Symbol)
+	if err != nil {
+		return err
+	}
+	mp.status.Add(1)
+	go ob.Begin()
+	mp.pool[p.Symbol] = ob
+	return nil
+}
+
+// open 创建盘口，配置了数据目录时从快照和日志恢复，下架的交易对重新上架
+func (mp *MatchPool) open(p conf.Pair) (*Orderbook, error) {
+	ob, err := NewOrderbook(mp.status, p, mp.mq)
+	if err != nil {
+		return nil, err
+	}
+	ob.provider = mp.position
+	if mp.data != nil && mp.data.Dir != "" {
+		if err := ob.Recover(mp.data); err != nil {
+			return nil, err
+		}
+	}
+	if ob.phase == models.PhaseDelisted { // 下架后重新上架，盘口订单已在下架时撤销
+		log.Printf("[%s] relist delisted pair\n", p.Symbol)
+		if err := ob.relist(); err != nil {
+			ob.closeJournal()
+			return nil, err
+		}
+	}
+	return ob, nil
+}
+
+// HaltPair 暂停交易对，拒绝新订单，保留盘口
+func (mp *MatchPool) HaltPair(pair string) error {
+	ob, err := mp.get(pair)
+	if err != nil {
+		return err
+	}
+	return ob.Halt()
+}
+
+// ResumePair 恢复交易对
+func (mp *MatchPool) ResumePair(pair string) error {
+	ob, err := mp.get(pair)
+	if err != nil {
+		return err
+	}
+	return ob.Resume()
+}
+
+// StartAuction 交易对开始集合竞价
+func (mp *MatchPool) StartAuction(pair string) error {
+	ob, err := mp.get(pair)
+	if err != nil {
+		return err
+	}
+	return ob.StartAuction()
+}
+
+// Uncross 交易对结束集合竞价，进入连续撮合
+func (mp *MatchPool) Uncross(pair string) error {
+	ob, err := mp.get(pair)
+	if err != nil {
+		return err
+	}
+	return ob.Uncross()
+}
+
+// DelistPair 下架交易对，撤销盘口全部订单后停止撮合协程。下架成功后才删除交易对，
+// 超时等失败时保留，避免撮合协程还在写日志时重新增加同一交易对
+func (mp *MatchPool) DelistPair(pair string) error {
+	ob, err := mp.get(pair)
+	if err != nil {
+		return err
+	}
+	if err := ob.Delist(); err != nil {
+		return err
+	}
+	mp.mu.Lock()
+	if mp.pool[pair] == ob {
+		delete(mp.pool, pair)
+	}
+	mp.mu.Unlock()
+	return nil
+}
+
+// get 查找交易对的盘口
+func (mp *MatchPool) get(pair string) (*Orderbook, error) {
+	mp.mu.RLock()
+	defer mp.mu.RUnlock()
+	ob, ok := mp.pool[pair]
+	if !ok {
+		return nil, ErrPair
+	}
+	return ob, nil
+}
+
 // AddOrder 挂单
 func (mp *MatchPool) AddOrder(order *models.Order) error {
-	if _, ok := mp.pool[order.Pair]; !ok {
-		return ErrPair
+	ob, err := mp.get(order.Pair)
+	if err != nil {
+		return err
+	}
+	return ob.Add(order)
+}
+
+// AddOrderSync 同步挂单，返回立即成交的成交单
+func (mp *MatchPool) AddOrderSync(order *models.Order) ([]models.Trade, error) {
+	ob, err := mp.get(order.Pair)
+	if err != nil {
+		return nil, err
 	}
-	return mp.pool[order.Pair].Add(order)
+	return ob.AddSync(order)
+}
+
+// AddOco 同步挂OCO订单，返回立即成交的成交单
+func (mp *MatchPool) AddOco(limit *models.Order, stop *models.Order) ([]models.Trade, error) {
+	ob, err := mp.get(limit.Pair)
+	if err != nil {
+		return nil, err
+	}
+	return ob.AddOco(limit, stop)
+}
+
+// AddBracket 同步挂括号订单，返回立即成交的成交单
+func (mp *MatchPool) AddBracket(entry *models.Order, profit *models.Order, stop *models.Order) ([]models.Trade, error) {
+	ob, err := mp.get(entry.Pair)
+	if err != nil {
+		return nil, err
+	}
+	return ob.AddBracket(entry, profit, stop)
+}
+
+// SyncPosition 用户持仓在撮合引擎之外变化(强平、其他交易对的保证金调整等)时，重新查询持仓并调整只减仓订单
+func (mp *MatchPool) SyncPosition(pair string, userId int64) error {
+	ob, err := mp.get(pair)
+	if err != nil {
+		return err
+	}
+	return ob.SyncPosition(userId)
 }
 
 // CancelOrder 撤单
 func (mp *MatchPool) CancelOrder(pair string, id string) error {
-	if _, ok := mp.pool[pair]; !ok {
-		return ErrPair
+	ob, err := mp.get(pair)
+	if err != nil {
+		return err
+	}
+	return ob.Cancel(id)
+}
+
+// AmendOrder 改单，返回改单事件和改价后立即成交的成交单
+func (mp *MatchPool) AmendOrder(pair string, id string, price decimal.Decimal, amount decimal.Decimal) ([]models.Trade, error) {
+	ob, err := mp.get(pair)
+	if err != nil {
+		return nil, err
+	}
+	return ob.Amend(id, price, amount)
+}
+
+// CancelAll 批量撤单，pair为空时撤销所有交易对，userId为0时撤销所有用户，side为空时撤销买卖双方，返回撤销的订单数
+func (mp *MatchPool) CancelAll(pair string, userId int64, side string) (int, error) {
+	if pair != "" {
+		ob, err := mp.get(pair)
+		if err != nil {
+			return 0, err
+		}
+		return ob.CancelAll(userId, side)
+	}
+	mp.mu.RLock()
+	obs := make([]*Orderbook, 0, len(mp.pool))
+	for _, ob := range mp.pool {
+		obs = append(obs, ob)
+	}
+	mp.mu.RUnlock()
+	count := 0
+	for _, ob := range obs {
+		n, err := ob.CancelAll(userId, side)
+		count += n
+		if err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+// GetDepth 查询深度
+func (mp *MatchPool) GetDepth(pair string, levels int) (*models.Depth, error) {
+	ob, err := mp.get(pair)
+	if err != nil {
+		return nil, err
 	}
-	return mp.pool[pair].Cancel(id)
+	return ob.Depth(levels)
 }
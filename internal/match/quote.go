@@ -0,0 +1,79 @@
+package match
+
+import (
+	"github.com/shopspring/decimal"
+	"lightning-engine/models"
+	"lightning-engine/pqueue/skiplist"
+)
+
+// matchQuote 按计价货币金额撮合市价单，逐个价格档位计算金额可以成交的数量，数量按交易对的LotSize向下取整。
+// 金额花完、对手盘为空或超出价格保护时结束，未花完的金额作为撤销记录推送
+func (ob *Orderbook) matchQuote(order *models.Order, makers book, index map[string]decimal.Decimal, crossed func(price decimal.Decimal) bool) []models.Trade {
+	trades := make([]models.Trade, 0)
+	for ob.phase == models.PhaseContinuous {
+		// 跳过金额不足以全部成交的全部成交订单，从第一个可以成交的maker所在的价格档位开始撮合
+		var first *skiplist.SkipListNode
+		for p := makers.First(); p != nil && crossed(p.Score()); p = p.Next(0) {
+			if maker := p.Value().(*models.Order); !maker.AllOrNone || !maker.Amount.GreaterThan(ob.affordable(order.QuoteAmount, p.Score())) {
+				first = p
+				break
+			}
+		}
+		if first == nil {
+			break
+		}
+		price := first.Score()
+		order.Amount = ob.affordable(order.QuoteAmount, price)
+		if !order.Amount.IsPositive() {
+			break
+		}
+
+		// 只与当前价格档位撮合
+		result := ob.match(order, makers, index, price.Equal)
+		trades = append(trades, result...)
+		cancelled := false
+		for _, trade := range result {
+			if trade.IsFill() {
+				order.QuoteAmount = order.QuoteAmount.Sub(decimal.RequireFromString(trade.Amount).Mul(price))
+			} else if trade.TakerOrderType == models.Stp && trade.TakerId == order.Id && trade.MakerId == order.Id {
+				if order.Stp != models.StpDecrementCancel {
+					cancelled = true // 自成交保护撤销了taker
+				} else { // 减去的数量按当前价格扣除金额，剩余金额继续撮合
+					order.QuoteAmount = order.QuoteAmount.Sub(decimal.RequireFromString(trade.Amount).Mul(price))
+				}
+			}
+		}
+		if cancelled {
+			break
+		}
+	}
+	order.Amount = decimal.Zero
+
+	// 判断金额是否花完，触发熔断时剩余金额按熔断撤销
+	if order.QuoteAmount.IsPositive() {
+		reason := models.ReasonQuoteUnspent
+		if ob.phase != models.PhaseContinuous {
+			reason = models.ReasonBreaker
+		}
+		trades = append(trades, eventTrade(order, models.Cancel, order.QuoteAmount, reason))
+	}
+	return trades
+}
+
+// affordable 金额在price价格下可以成交的数量，向下取整到LotSize
+func (ob *Orderbook) affordable(quote decimal.Decimal, price decimal.Decimal) decimal.Decimal {
+	step := ob.lot()
+	amount := quote.Div(price.Mul(step)).Floor().Mul(step)
+	if amount.Mul(price).GreaterThan(quote) { // 除法舍入导致超出金额
+		amount = amount.Sub(step)
+	}
+	return amount
+}
+
+// lot 数量取整的单位，未配置LotSize时为除法精度
+func (ob *Orderbook) lot() decimal.Decimal {
+	if ob.spec.LotSize.IsPositive() {
+		return ob.spec.LotSize
+	}
+	return decimal.New(1, -int32(decimal.DivisionPrecision))
+}
@@ -0,0 +1,245 @@
+package match
+
+import (
+	"github.com/shopspring/decimal"
+	"lightning-engine/internal/journal"
+	"lightning-engine/models"
+	"lightning-engine/pqueue/skiplist"
+	"sort"
+)
+
+// SyncPosition 用户持仓在撮合引擎之外变化(强平、减仓等)时重新查询持仓，持仓减少或反向时调整只减仓订单
+func (ob *Orderbook) SyncPosition(userId int64) error {
+	if ob.provider == nil {
+		return ErrPosition
+	}
+	return ob.admin(adminRequest{op: journal.OpPosition, userId: userId})
+}
+
+// query 写入日志前查询只减仓订单用户的持仓，记录在指令中，回放日志时不再查询持仓接口。
+// 已记录持仓的用户按成交累计持仓，只在SyncPosition时重新查询，避免每条指令都同步调用持仓接口
+func (ob *Orderbook) query(e *journal.Entry) {
+	if ob.provider == nil {
+		return
+	}
+	var order *models.Order
+	switch e.Op {
+	case journal.OpAdd:
+		order = e.Order
+	case journal.OpOco:
+		order = e.Order
+		if !order.IsReduceOnly() {
+			order = e.Link
+		}
+	case journal.OpAmend: // 改价或增加数量时重新挂单，需要重新限制数量
+		if s := ob.locate(e.Order.Id); s != nil {
+			if node, _ := s.orders.Find(s.index[e.Order.Id], e.Order.Id); node != nil {
+				order = node.Value().(*models.Order)
+			}
+		}
+	case journal.OpPosition:
+		order = &models.Order{UserId: e.UserId, ReduceOnly: true}
+	}
+	if order == nil || !order.IsReduceOnly() {
+		return
+	}
+	if _, ok := ob.held[order.UserId]; ok && e.Op != journal.OpPosition {
+		return
+	}
+	position := ob.provider.GetPosition(ob.pair, order.UserId)
+	e.UserId, e.Position = order.UserId, &position
+}
+
+// hold 记录指令中查询到的持仓
+func (ob *Orderbook) hold(e *journal.Entry) {
+	if e.Position != nil {
+		ob.held[e.UserId] = *e.Position
+	}
+}
+
+// closable 持仓可以减少的数量，买单减少空仓，卖单减少多仓
+func closable(side string, position decimal.Decimal) decimal.Decimal {
+	if side == models.Buy {
+		return decimal.Max(position.Neg(), decimal.Zero)
+	}
+	return decimal.Max(position, decimal.Zero)
+}
+
+// restrict 按持仓限制只减仓订单的数量，超出可减仓数量的部分撤销，不能减仓时拒绝并将order.Amount置为0。
+// 止损单只按持仓限制；其他订单还要减去同一方向盘口中的只减仓订单，保证全部成交也不会增加持仓。平仓订单的数量为可减仓数量
+func (ob *Orderbook) restrict(order *models.Order) []models.Trade {
+	position, ok := ob.held[order.UserId]
+	amount := closable(order.Side, position)
+	if ok && !order.IsStop() {
+		s := &side{ob.bid, ob.mBid}
+		if order.Side == models.Sell {
+			s = &side{ob.ask, ob.mAsk}
+		}
+		for _, node := range ob.reducing(order.UserId, s) {
+			amount = amount.Sub(node.Value().(*models.Order).Amount)
+		}
+	}
+	if !ok || !amount.IsPositive() { // 没有查询到持仓或不能减仓
+		trade := cancelTrade(order, models.ReasonReduceOnly)
+		order.Amount = decimal.Zero
+		return []models.Trade{trade}
+	}
+
+	trades := make([]models.Trade, 0)
+	if order.ClosePosition {
+		order.Amount = amount
+	} else if order.Amount.GreaterThan(amount) {
+		trades = append(trades, eventTrade(order, models.Cancel, order.Amount.Sub(amount), models.ReasonReduceOnly))
+		order.Amount = amount
+	}
+	ob.mark(order)
+	return trades
+}
+
+// mark 按用户记录只减仓订单id
+func (ob *Orderbook) mark(order *models.Order) {
+	ids, ok := ob.reduces[order.UserId]
+	if !ok {
+		ids = make(map[string]struct{})
+		ob.reduces[order.UserId] = ids
+	}
+	ids[order.Id] = struct{}{}
+}
+
+// reduce 按成交累计只减仓订单用户的持仓，调整持仓变化的用户的只减仓订单，返回改单和撤销记录
+func (ob *Orderbook) reduce(e *journal.Entry, trades []models.Trade) []models.Trade {
+	if len(ob.held) == 0 {
+		return nil
+	}
+	users := make(map[int64]struct{})
+	if e.Position != nil {
+		users[e.UserId] = struct{}{}
+	}
+	for _, trade := range trades {
+		if !trade.IsFill() {
+			continue
+		}
+		amount := decimal.RequireFromString(trade.Amount)
+		buyer, seller := trade.TakerUser, trade.MakerUser
+		if trade.TakerOrderSide == models.Sell {
+			buyer, seller = seller, buyer
+		}
+		if position, ok := ob.held[buyer]; ok {
+			ob.held[buyer] = position.Add(amount)
+			users[buyer] = struct{}{}
+		}
+		if position, ok := ob.held[seller]; ok {
+			ob.held[seller] = position.Sub(amount)
+			users[seller] = struct{}{}
+		}
+	}
+	if len(users) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(users))
+	for userId := range users {
+		ids = append(ids, userId)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	result := make([]models.Trade, 0)
+	for _, userId := range ids {
+		result = append(result, ob.adjust(userId)...)
+	}
+	return append(result, ob.unlinkTrades(result)...)
+}
+
+// adjust 按持仓调整用户的只减仓订单，用户没有只减仓订单时不再记录持仓
+func (ob *Orderbook) adjust(userId int64) []models.Trade {
+	position := ob.held[userId]
+	bids, bidStops, bidFound := ob.adjustSide(userId, closable(models.Buy, position), &side{ob.bid, ob.mBid}, &side{ob.stopBid, ob.mStopBid})
+	asks, askStops, askFound := ob.adjustSide(userId, closable(models.Sell, position), &side{ob.ask, ob.mAsk}, &side{ob.stopAsk, ob.mStopAsk})
+	if !bidFound && !askFound {
+		delete(ob.held, userId)
+	}
+	trades := append(bids, bidStops...)
+	trades = append(trades, asks...)
+	return append(trades, askStops...)
+}
+
+// adjustSide 调整用户一个方向的只减仓订单，返回盘口订单和止损单的调整记录，以及调整后是否还有只减仓订单。
+// 盘口订单的总数量超出可减仓数量时，从价格最差、时间最晚的订单开始减少数量或撤销；
+// 止损单的数量不超过可减仓数量，平仓止损单的数量等于可减仓数量；不能减仓时全部撤销
+func (ob *Orderbook) adjustSide(userId int64, amount decimal.Decimal, orders *side, stops *side) ([]models.Trade, []models.Trade, bool) {
+	found := false
+	trades := make([]models.Trade, 0)
+	nodes := ob.reducing(userId, orders)
+	excess := amount.Neg()
+	for _, node := range nodes {
+		excess = excess.Add(node.Value().(*models.Order).Amount)
+	}
+	for i := len(nodes) - 1; i >= 0; i-- {
+		order := nodes[i].Value().(*models.Order)
+		if !excess.IsPositive() {
+			found = true
+		} else if order.Amount.LessThanOrEqual(excess) {
+			excess = excess.Sub(order.Amount)
+			trades = append(trades, cancelTrade(order, models.ReasonReduceOnly))
+			remove(orders.orders, orders.index, nodes[i])
+		} else {
+			order.SetAmount(order.Amount.Sub(excess))
+			excess = decimal.Zero
+			trades = append(trades, eventTrade(order, models.Amend, order.Amount, models.ReasonReduceOnly))
+			found = true
+		}
+	}
+
+	stopTrades := make([]models.Trade, 0)
+	for _, node := range ob.reducing(userId, stops) {
+		order := node.Value().(*models.Order)
+		switch {
+		case !amount.IsPositive():
+			stopTrades = append(stopTrades, cancelTrade(order, models.ReasonReduceOnly))
+			remove(stops.orders, stops.index, node)
+			continue
+		case order.ClosePosition && !order.Amount.Equal(amount), order.Amount.GreaterThan(amount):
+			order.SetAmount(amount)
+			stopTrades = append(stopTrades, eventTrade(order, models.Amend, amount, models.ReasonReduceOnly))
+		}
+		found = true
+	}
+	return trades, stopTrades, found
+}
+
+// reducing 用户在一侧盘口或触发簿中的只减仓订单，按盘口顺序排列。顺便删除已成交或撤销的只减仓订单id
+func (ob *Orderbook) reducing(userId int64, s *side) []*skiplist.SkipListNode {
+	ids, ok := ob.reduces[userId]
+	if !ok {
+		return nil
+	}
+	nodes := make([]*skiplist.SkipListNode, 0, len(ids))
+	for id := range ids {
+		if ob.locate(id) == nil {
+			delete(ids, id)
+		} else if score, ok := s.index[id]; ok {
+			if node, _ := s.orders.Find(score, id); node != nil {
+				nodes = append(nodes, node)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		delete(ob.reduces, userId)
+	}
+	_, desc := s.orders.(*skiplist.SkipListDesc)
+	sort.Slice(nodes, func(i, j int) bool {
+		if a, b := nodes[i].Score(), nodes[j].Score(); !a.Equal(b) {
+			return a.GreaterThan(b) == desc
+		}
+		return rank(nodes[i]) < rank(nodes[j])
+	})
+	return nodes
+}
+
+// rank 节点在同一价格档位中的位置
+func rank(node *skiplist.SkipListNode) int {
+	n := 0
+	for p := node.Pre(); p != nil && p.Score().Equal(node.Score()); p = p.Pre() {
+		n++
+	}
+	return n
+}
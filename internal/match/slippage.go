@@ -0,0 +1,48 @@
+package match
+
+import (
+	"github.com/shopspring/decimal"
+	"lightning-engine/models"
+)
+
+// protect 市价单的价格保护，按到达时最优对手价和交易对的PriceBand计算可以成交的最差价格，
+// 订单设置了ProtectPrice时取两者中更严格的价格
+func (ob *Orderbook) protect(order *models.Order, makers book) func(price decimal.Decimal) bool {
+	limit := order.ProtectPrice
+	if ob.spec.PriceBand.IsPositive() && makers.First() != nil {
+		best := makers.First().Score()
+		if order.Side == models.Buy {
+			band := best.Mul(decimal.NewFromInt(1).Add(ob.spec.PriceBand))
+			if limit.IsZero() || band.LessThan(limit) {
+				limit = band
+			}
+		} else {
+			band := best.Mul(decimal.NewFromInt(1).Sub(ob.spec.PriceBand))
+			if band.IsPositive() && band.GreaterThan(limit) {
+				limit = band
+			}
+		}
+	}
+	if limit.IsZero() {
+		return anyPrice
+	}
+	if order.Side == models.Buy {
+		return limit.GreaterThanOrEqual
+	}
+	return limit.LessThanOrEqual
+}
+
+// slippage 市价单剩余部分的撤销原因，跳过不能一次全部成交的全部成交订单后，第一个可以成交的maker超出价格保护时为slippage；
+// 对手盘耗尽或只剩不能成交的全部成交订单时为空
+func slippage(order *models.Order, makers book, crossed func(price decimal.Decimal) bool) string {
+	for p := makers.First(); p != nil; p = p.Next(0) {
+		if maker := p.Value().(*models.Order); maker.AllOrNone && maker.Amount.GreaterThan(order.Amount) {
+			continue
+		}
+		if !crossed(p.Score()) {
+			return models.ReasonSlippage
+		}
+		break
+	}
+	return ""
+}
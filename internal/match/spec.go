@@ -0,0 +1,123 @@
+package match
+
+import (
+	"fmt"
+	"github.com/shopspring/decimal"
+	"lightning-engine/internal/conf"
+	"lightning-engine/models"
+)
+
+// checkPair 校验交易对配置
+func checkPair(p conf.Pair) error {
+	if p.Symbol == "" || p.PriceScale < 0 || p.AmountScale < 0 || p.BreakerWindow < 0 || p.BreakerDuration < 0 {
+		return fmt.Errorf("%w: %s", ErrPairSpec, p.Symbol)
+	}
+	for _, d := range []decimal.Decimal{p.LotSize, p.PriceBand, p.PriceTick, p.MinAmount, p.MaxAmount, p.MinNotional, p.MaxNotional, p.BreakerPercent} {
+		if d.IsNegative() {
+			return fmt.Errorf("%w: %s", ErrPairSpec, p.Symbol)
+		}
+	}
+	if p.MaxAmount.IsPositive() && p.MinAmount.GreaterThan(p.MaxAmount) ||
+		p.MaxNotional.IsPositive() && p.MinNotional.GreaterThan(p.MaxNotional) {
+		return fmt.Errorf("%w: %s", ErrPairSpec, p.Symbol)
+	}
+	switch p.Allocation {
+	case "", conf.AllocationFifo, conf.AllocationProRata:
+		if !p.AllocationTop.IsZero() {
+			return fmt.Errorf("%w: %s", ErrPairSpec, p.Symbol)
+		}
+	case conf.AllocationHybrid:
+		if !p.AllocationTop.IsPositive() || p.AllocationTop.GreaterThan(decimal.NewFromInt(1)) {
+			return fmt.Errorf("%w: %s", ErrPairSpec, p.Symbol)
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrPairSpec, p.Symbol)
+	}
+	return nil
+}
+
+// check 按交易对配置校验订单的价格、数量和金额，在validate之后调用。只减仓订单需要配置持仓接口
+func (ob *Orderbook) check(order *models.Order) error {
+	if order.IsReduceOnly() && ob.provider == nil {
+		return ErrPosition
+	}
+	if order.Type == models.Limit || order.Type == models.StopLimit {
+		if err := ob.checkPrice(order.Price); err != nil {
+			return err
+		}
+	}
+	for _, price := range []decimal.Decimal{order.TriggerPrice, order.ProtectPrice, order.TrailingAmount, order.PegOffset, order.PegLimit} {
+		if err := ob.checkPrice(price); err != nil {
+			return err
+		}
+	}
+	if order.IsQuote() { // 按金额下单的市价单只检查金额
+		return ob.checkNotional(order.QuoteAmount)
+	}
+	if err := ob.checkAmount(order.Amount); err != nil {
+		return err
+	}
+	if err := ob.checkStep(order.DisplayAmount); err != nil {
+		return err
+	}
+	if err := ob.checkStep(order.MinQty); err != nil {
+		return err
+	}
+	if (order.Type == models.Limit || order.Type == models.StopLimit) && order.Peg == "" { // 挂钩单的价格由撮合引擎确定，不检查金额
+		return ob.checkNotional(order.Price.Mul(order.Amount))
+	}
+	return nil
+}
+
+// checkPrice 校验价格精度和最小变动单位，价格为0时不检查
+func (ob *Orderbook) checkPrice(price decimal.Decimal) error {
+	if price.IsZero() {
+		return nil
+	}
+	if ob.spec.PriceScale > 0 && !price.Equal(price.Truncate(ob.spec.PriceScale)) {
+		return ErrPriceScale
+	}
+	if ob.spec.PriceTick.IsPositive() && !price.Mod(ob.spec.PriceTick).IsZero() {
+		return ErrPriceTick
+	}
+	return nil
+}
+
+// checkAmount 校验数量精度、最小变动单位和上下限，数量为0时不检查
+func (ob *Orderbook) checkAmount(amount decimal.Decimal) error {
+	if amount.IsZero() {
+		return nil
+	}
+	if err := ob.checkStep(amount); err != nil {
+		return err
+	}
+	if ob.spec.MinAmount.IsPositive() && amount.LessThan(ob.spec.MinAmount) {
+		return ErrMinAmount
+	}
+	if ob.spec.MaxAmount.IsPositive() && amount.GreaterThan(ob.spec.MaxAmount) {
+		return ErrMaxAmount
+	}
+	return nil
+}
+
+// checkStep 校验数量精度和最小变动单位
+func (ob *Orderbook) checkStep(amount decimal.Decimal) error {
+	if ob.spec.AmountScale > 0 && !amount.Equal(amount.Truncate(ob.spec.AmountScale)) {
+		return ErrAmountScale
+	}
+	if ob.spec.LotSize.IsPositive() && !amount.Mod(ob.spec.LotSize).IsZero() {
+		return ErrAmountStep
+	}
+	return nil
+}
+
+// checkNotional 校验金额上下限
+func (ob *Orderbook) checkNotional(notional decimal.Decimal) error {
+	if ob.spec.MinNotional.IsPositive() && notional.LessThan(ob.spec.MinNotional) {
+		return ErrMinNotional
+	}
+	if ob.spec.MaxNotional.IsPositive() && notional.GreaterThan(ob.spec.MaxNotional) {
+		return ErrMaxNotional
+	}
+	return nil
+}
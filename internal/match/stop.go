@@ -0,0 +1,120 @@
+package match
+
+import (
+	"github.com/shopspring/decimal"
+	"lightning-engine/models"
+	"lightning-engine/utils"
+)
+
+// addBidStop 挂bid止损单，放入触发簿，最新成交价>=触发价时触发
+func (ob *Orderbook) addBidStop(order models.Order) ([]models.Trade, error) {
+	if order.IsTrailing() && !ob.startTrail(&order) { // 没有最新成交价或触发价无效，无法计算触发价
+		return []models.Trade{cancelTrade(&order, models.ReasonStopRejected)}, nil
+	}
+	if !ob.lastPrice.IsZero() && ob.lastPrice.GreaterThanOrEqual(order.TriggerPrice) { // 已满足触发条件，拒绝
+		return []models.Trade{cancelTrade(&order, models.ReasonStopRejected)}, nil
+	}
+	ob.stopBid.Insert(order.TriggerPrice, &order)
+	ob.mStopBid[order.Id] = order.TriggerPrice
+	ob.schedule(&order)
+	return ob.track(&order), nil
+}
+
+// addAskStop 挂ask止损单，放入触发簿，最新成交价<=触发价时触发
+func (ob *Orderbook) addAskStop(order models.Order) ([]models.Trade, error) {
+	if order.IsTrailing() && !ob.startTrail(&order) { // 没有最新成交价或触发价无效，无法计算触发价
+		return []models.Trade{cancelTrade(&order, models.ReasonStopRejected)}, nil
+	}
+	if !ob.lastPrice.IsZero() && ob.lastPrice.LessThanOrEqual(order.TriggerPrice) { // 已满足触发条件，拒绝
+		return []models.Trade{cancelTrade(&order, models.ReasonStopRejected)}, nil
+	}
+	ob.stopAsk.Insert(order.TriggerPrice, &order)
+	ob.mStopAsk[order.Id] = order.TriggerPrice
+	ob.schedule(&order)
+	return ob.track(&order), nil
+}
+
+// setLastPrice 根据成交单更新最新成交价
+func (ob *Orderbook) setLastPrice(trades []models.Trade) {
+	for i := len(trades) - 1; i >= 0; i-- {
+		if trades[i].IsFill() {
+			ob.lastPrice = decimal.RequireFromString(trades[i].Price)
+			return
+		}
+	}
+}
+
+// trigger 按最新成交价触发止损单，触发后的订单进入正常撮合，产生的成交可能继续触发其他止损单
+func (ob *Orderbook) trigger() []models.Trade {
+	trades := make([]models.Trade, 0)
+	if ob.lastPrice.IsZero() {
+		return trades
+	}
+	for ob.phase == models.PhaseContinuous { // 集合竞价、暂停交易时不触发，触发后的订单可能引起熔断
+		var order models.Order
+		if first := ob.stopBid.First(); first != nil && ob.lastPrice.GreaterThanOrEqual(first.Score()) {
+			order = *first.Value().(*models.Order)
+			ob.stopBid.Delete(first.Score(), order.Id)
+			delete(ob.mStopBid, order.Id)
+		} else if first := ob.stopAsk.First(); first != nil && ob.lastPrice.LessThanOrEqual(first.Score()) {
+			order = *first.Value().(*models.Order)
+			ob.stopAsk.Delete(first.Score(), order.Id)
+			delete(ob.mStopAsk, order.Id)
+		} else {
+			return trades
+		}
+
+		trades = append(trades, models.Trade{
+			Id:               utils.GenTradeId(),
+			Pair:             order.Pair,
+			MakerId:          order.Id,
+			TakerId:          order.Id,
+			MakerUser:        order.UserId,
+			TakerUser:        order.UserId,
+			Price:            ob.lastPrice.String(),
+			Amount:           order.Amount.String(),
+			TakerOrderSide:   order.Side,
+			TakerOrderType:   models.Trigger,
+			TakerTimeInForce: order.TimeInForce,
+			Ts:               utils.NowUnixMilli(),
+		})
+		trades = append(trades, ob.unlink(order.Id)...)
+		if order.Type == models.StopMarket {
+			order.Type = models.Market
+		} else {
+			order.Type = models.Limit
+		}
+		result, _ := ob.add(order)
+		result = append(result, ob.unlinkTrades(result)...)
+		ob.setLastPrice(result)
+		result = append(result, ob.trail(result)...)
+		trades = append(trades, result...)
+	}
+	return trades
+}
+
+// cancelStopBid 撤销bid止损单
+func (ob *Orderbook) cancelStopBid(score decimal.Decimal, id string) error {
+	node, _ := ob.stopBid.Find(score, id)
+	if node == nil {
+		return ErrOrderId
+	}
+	order := node.Value().(*models.Order)
+	ob.stopBid.Delete(score, id)
+	delete(ob.mStopBid, id)
+	ob.PushTrades(cancelTrade(order, ""))
+	return nil
+}
+
+// cancelStopAsk 撤销ask止损单
+func (ob *Orderbook) cancelStopAsk(score decimal.Decimal, id string) error {
+	node, _ := ob.stopAsk.Find(score, id)
+	if node == nil {
+		return ErrOrderId
+	}
+	order := node.Value().(*models.Order)
+	ob.stopAsk.Delete(score, id)
+	delete(ob.mStopAsk, id)
+	ob.PushTrades(cancelTrade(order, ""))
+	return nil
+}
@@ -0,0 +1,37 @@
+package match
+
+import (
+	"github.com/shopspring/decimal"
+	"lightning-engine/models"
+	"lightning-engine/pqueue/skiplist"
+)
+
+// selfTrade 按taker的自成交保护模式处理taker与同一用户的maker，返回撤销记录
+func (ob *Orderbook) selfTrade(order *models.Order, node *skiplist.SkipListNode, makers book, index map[string]decimal.Decimal) []models.Trade {
+	maker := node.Value().(*models.Order)
+	trades := make([]models.Trade, 0, 2)
+	switch order.Stp {
+	case models.StpCancelNewest: // 撤销taker剩余数量
+		trades = append(trades, eventTrade(order, models.Stp, order.Amount, order.Stp))
+		order.Amount = decimal.Zero
+	case models.StpCancelOldest: // 撤销maker，taker继续撮合
+		trades = append(trades, eventTrade(maker, models.Stp, maker.TotalAmount(), order.Stp))
+		remove(makers, index, node)
+	case models.StpCancelBoth: // 撤销taker剩余数量和maker
+		trades = append(trades, eventTrade(order, models.Stp, order.Amount, order.Stp))
+		trades = append(trades, eventTrade(maker, models.Stp, maker.TotalAmount(), order.Stp))
+		order.Amount = decimal.Zero
+		remove(makers, index, node)
+	case models.StpDecrementCancel: // 双方减去较小的数量，数量为0的一方撤销，taker有剩余时继续撮合
+		amount := decimal.Min(order.Amount, maker.Amount)
+		trades = append(trades, eventTrade(order, models.Stp, amount, order.Stp))
+		trades = append(trades, eventTrade(maker, models.Stp, amount, order.Stp))
+		order.Amount = order.Amount.Sub(amount)
+		if maker.Amount.GreaterThan(amount) {
+			maker.SetAmount(maker.Amount.Sub(amount))
+		} else if !replenish(makers, node) {
+			remove(makers, index, node)
+		}
+	}
+	return trades
+}
@@ -0,0 +1,113 @@
+package match
+
+import (
+	"github.com/shopspring/decimal"
+	"lightning-engine/models"
+	"sort"
+)
+
+// startTrail 跟踪止损单挂单时以最新成交价作为最高/最低成交价计算触发价，没有最新成交价或触发价不为正数(卖单价差不小于最新成交价)时返回false
+func (ob *Orderbook) startTrail(order *models.Order) bool {
+	if ob.lastPrice.IsZero() {
+		return false
+	}
+	order.Watermark = ob.lastPrice
+	order.TriggerPrice = trailTrigger(order)
+	return order.TriggerPrice.IsPositive()
+}
+
+// track 跟踪止损单进入触发簿后开始跟踪成交价，返回初始触发价的更新记录
+func (ob *Orderbook) track(order *models.Order) []models.Trade {
+	if !order.IsTrailing() {
+		return nil
+	}
+	ob.trails[order.Id] = struct{}{}
+	return []models.Trade{trailTrade(order)}
+}
+
+// trailTrigger 按最高/最低成交价计算触发价，卖单为最高价减去价差，买单为最低价加上价差
+func trailTrigger(order *models.Order) decimal.Decimal {
+	offset := order.TrailingAmount
+	if order.TrailingPercent.IsPositive() {
+		offset = order.Watermark.Mul(order.TrailingPercent)
+	}
+	if order.Side == models.Sell {
+		return order.Watermark.Sub(offset)
+	}
+	return order.Watermark.Add(offset)
+}
+
+// trail 按成交单的最高/最低成交价更新跟踪止损单的触发价，返回触发价的更新记录。
+// 在trigger之前调用，成交价回落(卖单)或反弹(买单)到新的触发价时由trigger触发
+func (ob *Orderbook) trail(trades []models.Trade) []models.Trade {
+	if len(ob.trails) == 0 {
+		return nil
+	}
+	var high, low decimal.Decimal
+	for _, trade := range trades {
+		if !trade.IsFill() {
+			continue
+		}
+		price := decimal.RequireFromString(trade.Price)
+		if high.IsZero() || price.GreaterThan(high) {
+			high = price
+		}
+		if low.IsZero() || price.LessThan(low) {
+			low = price
+		}
+	}
+	if high.IsZero() {
+		return nil
+	}
+
+	// 按订单id排序，回放时更新顺序一致
+	ids := make([]string, 0, len(ob.trails))
+	for id := range ob.trails {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	result := make([]models.Trade, 0)
+	for _, id := range ids {
+		var orders book = ob.stopAsk
+		index := ob.mStopAsk
+		score, ok := index[id]
+		if !ok {
+			orders, index = ob.stopBid, ob.mStopBid
+			score, ok = index[id]
+		}
+		if !ok { // 已触发或撤销
+			delete(ob.trails, id)
+			continue
+		}
+		node, _ := orders.Find(score, id)
+		if node == nil {
+			delete(ob.trails, id)
+			continue
+		}
+		order := node.Value().(*models.Order)
+		if order.Side == models.Sell && high.GreaterThan(order.Watermark) {
+			order.Watermark = high
+		} else if order.Side == models.Buy && low.LessThan(order.Watermark) {
+			order.Watermark = low
+		} else {
+			continue
+		}
+		trigger := trailTrigger(order)
+		if trigger.Equal(order.TriggerPrice) {
+			continue
+		}
+		orders.Delete(score, id)
+		order.TriggerPrice = trigger
+		orders.Insert(trigger, order)
+		index[id] = trigger
+		result = append(result, trailTrade(order))
+	}
+	return result
+}
+
+// trailTrade 跟踪止损单触发价更新记录，Price为新的触发价
+func trailTrade(order *models.Order) models.Trade {
+	trade := eventTrade(order, models.Trail, order.Amount, "")
+	trade.Price = order.TriggerPrice.String()
+	return trade
+}
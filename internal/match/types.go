@@ -1,14 +1,96 @@
 package match
 
-import "errors"
+import (
+	"errors"
+	"github.com/shopspring/decimal"
+	"lightning-engine/models"
+	"lightning-engine/pqueue"
+	"lightning-engine/pqueue/skiplist"
+)
 
 var (
 	ErrMq               = errors.New("mq cannot nil")
 	ErrTimeout          = errors.New("timeout")
 	ErrClosed           = errors.New("match server closed")
 	ErrOrderSide        = errors.New("order side error (buy/sell)")
-	ErrOrderType        = errors.New("order type error (limit/market)")
-	ErrOrderTimeInForce = errors.New("order timeInForce error (GTC/IOC/FOK)")
+	ErrOrderType        = errors.New("order type error (limit/market/stop_market/stop_limit)")
+	ErrOrderTimeInForce = errors.New("order timeInForce error (GTC/IOC/FOK/GTD)")
 	ErrOrderId          = errors.New("order id error")
+	ErrOrderPrice       = errors.New("order price error")
+	ErrOrderAmount      = errors.New("order amount error")
+	ErrTriggerPrice     = errors.New("order trigger price error")
+	ErrPostOnly         = errors.New("order post only error (reject/reprice, limit GTC/GTD only)")
+	ErrDisplayAmount    = errors.New("order display amount error (0 < display < amount, limit GTC/GTD only)")
+	ErrExpireTime       = errors.New("order expire time error (GTD only, must be in the future)")
+	ErrQuoteAmount      = errors.New("order quote amount error (market only, amount must be empty)")
+	ErrProtectPrice     = errors.New("order protect price error (market/stop_market only)")
+	ErrStp              = errors.New("order stp error (cancel_newest/cancel_oldest/cancel_both/decrement_cancel)")
+	ErrTrailing         = errors.New("order trailing error (stop only without trigger price, amount or 0 < percent < 1)")
+	ErrInvisible        = errors.New("order hidden error (limit GTC/GTD only, not iceberg)")
+	ErrPeg              = errors.New("order peg error (primary/midpoint, limit GTC/GTD only, price must be empty)")
+	ErrMinQty           = errors.New("order min qty error (limit GTC/GTD/IOC only, 0 < min qty <= amount)")
+	ErrAllOrNone        = errors.New("order all or none error (limit GTC/GTD only, not iceberg or peg, without min qty)")
+	ErrReduceOnly       = errors.New("order reduce only error (not quote or iceberg, close position market/stop_market only without amount)")
+	ErrPosition         = errors.New("position provider not configured")
+	ErrBracket          = errors.New("bracket orders error (limit entry, take profit limit GTC/GTD and stop on the opposite side, not reduce only)")
+	ErrOco              = errors.New("oco orders error (limit GTC/GTD and stop, same pair, user and side)")
 	ErrPair             = errors.New("pair error")
+	ErrPairExists       = errors.New("pair already exists")
+	ErrHalted           = errors.New("pair halted")
+	ErrDelisted         = errors.New("pair delisted")
+	ErrPhase            = errors.New("pair phase error")
+	ErrPairSpec         = errors.New("pair spec error")
+	ErrPriceTick        = errors.New("order price is not a multiple of the pair price tick")
+	ErrPriceScale       = errors.New("order price has too many decimal places")
+	ErrAmountStep       = errors.New("order amount is not a multiple of the pair lot size")
+	ErrAmountScale      = errors.New("order amount has too many decimal places")
+	ErrMinAmount        = errors.New("order amount is less than the pair min amount")
+	ErrMaxAmount        = errors.New("order amount is greater than the pair max amount")
+	ErrMinNotional      = errors.New("order notional is less than the pair min notional")
+	ErrMaxNotional      = errors.New("order notional is greater than the pair max notional")
+	ErrJournalOp        = errors.New("journal op error")
+	ErrJournal          = errors.New("write journal error")
 )
+
+// addRequest 挂单请求，reply不为nil时同步返回撮合结果
+type addRequest struct {
+	order models.Order
+	link  *models.Order // OCO订单的止损单，不为nil时order为限价单
+	reply chan addResult
+
+	profit *models.Order // 括号订单的止盈单，不为nil时order为父订单，link为止损单
+}
+
+// addResult 同步挂单的撮合结果
+type addResult struct {
+	trades []models.Trade // 立即成交的成交单(包括撤销)
+	err    error          // 订单被拒绝的原因
+}
+
+// depthRequest 深度查询请求，由撮合协程处理后通过reply返回
+type depthRequest struct {
+	levels int                // 档位数，<=0时返回全部档位
+	reply  chan *models.Depth // 返回结果
+}
+
+// massRequest 批量撤单请求，由撮合协程处理后通过reply返回
+type massRequest struct {
+	userId int64  // 用户id，0表示全部用户
+	side   string // 订单方向，为空表示买卖双方
+	reply  chan addResult
+}
+
+// adminRequest 交易对管理请求(暂停、恢复、下架、持仓同步)，由撮合协程处理后通过reply返回
+type adminRequest struct {
+	op     string // 日志指令类型
+	userId int64  // 持仓同步请求的用户id
+	reply  chan error
+}
+
+// book 盘口一侧的订单，SkipList和SkipListDesc都实现了该接口
+type book interface {
+	Insert(score decimal.Decimal, value pqueue.INodeValue) *skiplist.SkipListNode
+	Find(score decimal.Decimal, id string) (*skiplist.SkipListNode, []*skiplist.SkipListNode)
+	Delete(score decimal.Decimal, id string)
+	First() *skiplist.SkipListNode
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	gstatus "google.golang.org/grpc/status"
+	pb "lightning-engine/api/match/v1"
+	"lightning-engine/internal/conf"
+	"lightning-engine/internal/match"
+)
+
+// AdminServer 交易对管理服务，应只对内部管理系统开放
+type AdminServer struct {
+	pb.UnimplementedAdminServiceServer
+	pool *match.MatchPool
+}
+
+func NewAdminServer(pool *match.MatchPool) *AdminServer {
+	return &AdminServer{
+		pool: pool,
+	}
+}
+
+// AddPair 增加交易对
+func (s *AdminServer) AddPair(ctx context.Context, in *pb.AddPairRequest) (*pb.AddPairReply, error) {
+	if in.Pair == nil {
+		return &pb.AddPairReply{Result: &pb.ReplyResult{Code: 400, Msg: "pair error"}}, gstatus.Error(codes.InvalidArgument, "pair error")
+	}
+	spec := conf.Pair{Symbol: in.Pair.Symbol, PriceScale: in.Pair.PriceScale, AmountScale: in.Pair.AmountScale}
+	spec.BreakerWindow, spec.BreakerDuration, spec.BreakerAuction = in.Pair.BreakerWindow, in.Pair.BreakerDuration, in.Pair.BreakerAuction
+	spec.Allocation = in.Pair.Allocation
+	fields := []struct {
+		value string
+		dst   *decimal.Decimal
+	}{
+		{in.Pair.LotSize, &spec.LotSize},
+		{in.Pair.PriceBand, &spec.PriceBand},
+		{in.Pair.PriceTick, &spec.PriceTick},
+		{in.Pair.MinAmount, &spec.MinAmount},
+		{in.Pair.MaxAmount, &spec.MaxAmount},
+		{in.Pair.MinNotional, &spec.MinNotional},
+		{in.Pair.MaxNotional, &spec.MaxNotional},
+		{in.Pair.BreakerPercent, &spec.BreakerPercent},
+		{in.Pair.AllocationTop, &spec.AllocationTop},
+	}
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		d, err := decimal.NewFromString(f.value)
+		if err != nil {
+			return &pb.AddPairReply{Result: &pb.ReplyResult{Code: 400, Msg: "pair spec error"}}, gstatus.Error(codes.InvalidArgument, "pair spec error")
+		}
+		*f.dst = d
+	}
+	if err := s.pool.AddPair(spec); err != nil {
+		return &pb.AddPairReply{Result: &pb.ReplyResult{Code: 400, Msg: err.Error()}}, statusError(err)
+	}
+	return &pb.AddPairReply{Result: &pb.ReplyResult{Code: 0, Msg: "success"}}, nil
+}
+
+// HaltPair 暂停交易对
+func (s *AdminServer) HaltPair(ctx context.Context, in *pb.HaltPairRequest) (*pb.HaltPairReply, error) {
+	if err := s.pool.HaltPair(in.Pair); err != nil {
+		return &pb.HaltPairReply{Result: &pb.ReplyResult{Code: 400, Msg: err.Error()}}, statusError(err)
+	}
+	return &pb.HaltPairReply{Result: &pb.ReplyResult{Code: 0, Msg: "success"}}, nil
+}
+
+// ResumePair 恢复交易对
+func (s *AdminServer) ResumePair(ctx context.Context, in *pb.ResumePairRequest) (*pb.ResumePairReply, error) {
+	if err := s.pool.ResumePair(in.Pair); err != nil {
+		return &pb.ResumePairReply{Result: &pb.ReplyResult{Code: 400, Msg: err.Error()}}, statusError(err)
+	}
+	return &pb.ResumePairReply{Result: &pb.ReplyResult{Code: 0, Msg: "success"}}, nil
+}
+
+// DelistPair 下架交易对
+func (s *AdminServer) DelistPair(ctx context.Context, in *pb.DelistPairRequest) (*pb.DelistPairReply, error) {
+	if err := s.pool.DelistPair(in.Pair); err != nil {
+		return &pb.DelistPairReply{Result: &pb.ReplyResult{Code: 400, Msg: err.Error()}}, statusError(err)
+	}
+	return &pb.DelistPairReply{Result: &pb.ReplyResult{Code: 0, Msg: "success"}}, nil
+}
+
+// StartAuction 开始集合竞价
+func (s *AdminServer) StartAuction(ctx context.Context, in *pb.StartAuctionRequest) (*pb.StartAuctionReply, error) {
+	if err := s.pool.StartAuction(in.Pair); err != nil {
+		return &pb.StartAuctionReply{Result: &pb.ReplyResult{Code: 400, Msg: err.Error()}}, statusError(err)
+	}
+	return &pb.StartAuctionReply{Result: &pb.ReplyResult{Code: 0, Msg: "success"}}, nil
+}
+
+// Uncross 结束集合竞价
+func (s *AdminServer) Uncross(ctx context.Context, in *pb.UncrossRequest) (*pb.UncrossReply, error) {
+	if err := s.pool.Uncross(in.Pair); err != nil {
+		return &pb.UncrossReply{Result: &pb.ReplyResult{Code: 400, Msg: err.Error()}}, statusError(err)
+	}
+	return &pb.UncrossReply{Result: &pb.ReplyResult{Code: 0, Msg: "success"}}, nil
+}
@@ -2,7 +2,10 @@ package server
 
 import (
 	"context"
+	"errors"
 	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	gstatus "google.golang.org/grpc/status"
 	pb "lightning-engine/api/match/v1"
 	"lightning-engine/internal/match"
 	"lightning-engine/internal/status"
@@ -23,36 +26,331 @@ func NewServer(status *status.Status, pool *match.MatchPool) *Server {
 }
 
 func (s *Server) AddOrder(ctx context.Context, in *pb.AddOrderRequest) (*pb.AddOrderReply, error) {
-	price, err := decimal.NewFromString(in.Order.Price)
+	if in.Order == nil {
+		return &pb.AddOrderReply{Result: &pb.ReplyResult{Code: 400, Msg: "order error"}}, gstatus.Error(codes.InvalidArgument, "order error")
+	}
+	order, err := toOrder(in.Order)
 	if err != nil {
-		return &pb.AddOrderReply{Result: &pb.ReplyResult{Code: 400, Msg: "price error"}}, err
+		return &pb.AddOrderReply{Result: &pb.ReplyResult{Code: 400, Msg: err.Error()}}, gstatus.Error(codes.InvalidArgument, err.Error())
+	}
+	if !in.Sync {
+		err = s.pool.AddOrder(order)
+		if err != nil {
+			return &pb.AddOrderReply{Result: &pb.ReplyResult{Code: 400, Msg: err.Error()}}, statusError(err)
+		}
+		return &pb.AddOrderReply{Result: &pb.ReplyResult{Code: 0, Msg: "success"}}, nil
 	}
-	amount, err := decimal.NewFromString(in.Order.Amount)
+
+	// 同步挂单，等待撮合结果
+	trades, err := s.pool.AddOrderSync(order)
 	if err != nil {
-		return &pb.AddOrderReply{Result: &pb.ReplyResult{Code: 400, Msg: "amount error"}}, err
+		return &pb.AddOrderReply{Result: &pb.ReplyResult{Code: 400, Msg: err.Error()}}, statusError(err)
+	}
+	accepted, reason := match.Accepted(order, trades)
+	return &pb.AddOrderReply{
+		Result:   &pb.ReplyResult{Code: 0, Msg: "success"},
+		Accepted: accepted,
+		Trades:   toTrades(trades),
+		Reason:   reason,
+	}, nil
+}
+
+// AddOco 挂OCO订单，同步返回撮合结果
+func (s *Server) AddOco(ctx context.Context, in *pb.AddOcoRequest) (*pb.AddOcoReply, error) {
+	if in.Limit == nil || in.Stop == nil {
+		return &pb.AddOcoReply{Result: &pb.ReplyResult{Code: 400, Msg: "order error"}}, gstatus.Error(codes.InvalidArgument, "order error")
 	}
-	order := &models.Order{
-		Id:          in.Order.Id,
-		UserId:      in.Order.UserId,
-		Pair:        in.Order.Pair,
-		Price:       price,
-		Amount:      amount,
-		Side:        in.Order.Side,
-		Type:        in.Order.Type,
-		TimeInForce: in.Order.TimeInForce,
+	limit, err := toOrder(in.Limit)
+	if err != nil {
+		return &pb.AddOcoReply{Result: &pb.ReplyResult{Code: 400, Msg: err.Error()}}, gstatus.Error(codes.InvalidArgument, err.Error())
 	}
-	err = s.pool.AddOrder(order)
+	stop, err := toOrder(in.Stop)
 	if err != nil {
-		return &pb.AddOrderReply{Result: &pb.ReplyResult{Code: 400, Msg: err.Error()}}, err
+		return &pb.AddOcoReply{Result: &pb.ReplyResult{Code: 400, Msg: err.Error()}}, gstatus.Error(codes.InvalidArgument, err.Error())
 	}
-	return &pb.AddOrderReply{Result: &pb.ReplyResult{Code: 0, Msg: "success"}}, nil
+	trades, err := s.pool.AddOco(limit, stop)
+	if err != nil {
+		return &pb.AddOcoReply{Result: &pb.ReplyResult{Code: 400, Msg: err.Error()}}, statusError(err)
+	}
+	return &pb.AddOcoReply{Result: &pb.ReplyResult{Code: 0, Msg: "success"}, Trades: toTrades(trades)}, nil
 }
 
 // CancelOrder 撤单
 func (s *Server) CancelOrder(ctx context.Context, in *pb.CancelOrderRequest) (*pb.CancelOrderReply, error) {
 	err := s.pool.CancelOrder(in.Pair, in.Id)
 	if err != nil {
-		return &pb.CancelOrderReply{Result: &pb.ReplyResult{Code: 400, Msg: err.Error()}}, err
+		return &pb.CancelOrderReply{Result: &pb.ReplyResult{Code: 400, Msg: err.Error()}}, statusError(err)
 	}
 	return &pb.CancelOrderReply{Result: &pb.ReplyResult{Code: 0, Msg: "success"}}, nil
 }
+
+// CancelAll 批量撤单
+func (s *Server) CancelAll(ctx context.Context, in *pb.CancelAllRequest) (*pb.CancelAllReply, error) {
+	count, err := s.pool.CancelAll(in.Pair, in.UserId, in.Side)
+	if err != nil {
+		return &pb.CancelAllReply{Result: &pb.ReplyResult{Code: 400, Msg: err.Error()}, Count: int64(count)}, statusError(err)
+	}
+	return &pb.CancelAllReply{Result: &pb.ReplyResult{Code: 0, Msg: "success"}, Count: int64(count)}, nil
+}
+
+// AddBracket 挂括号订单，同步返回撮合结果
+func (s *Server) AddBracket(ctx context.Context, in *pb.AddBracketRequest) (*pb.AddBracketReply, error) {
+	if in.Entry == nil || in.Profit == nil || in.Stop == nil {
+		return &pb.AddBracketReply{Result: &pb.ReplyResult{Code: 400, Msg: "order error"}}, gstatus.Error(codes.InvalidArgument, "order error")
+	}
+	orders := make([]*models.Order, 0, 3)
+	for _, o := range []*pb.Order{in.Entry, in.Profit, in.Stop} {
+		order, err := toOrder(o)
+		if err != nil {
+			return &pb.AddBracketReply{Result: &pb.ReplyResult{Code: 400, Msg: err.Error()}}, gstatus.Error(codes.InvalidArgument, err.Error())
+		}
+		orders = append(orders, order)
+	}
+	trades, err := s.pool.AddBracket(orders[0], orders[1], orders[2])
+	if err != nil {
+		return &pb.AddBracketReply{Result: &pb.ReplyResult{Code: 400, Msg: err.Error()}}, statusError(err)
+	}
+	return &pb.AddBracketReply{Result: &pb.ReplyResult{Code: 0, Msg: "success"}, Trades: toTrades(trades)}, nil
+}
+
+// SyncPosition 同步用户持仓，调整只减仓订单
+func (s *Server) SyncPosition(ctx context.Context, in *pb.SyncPositionRequest) (*pb.SyncPositionReply, error) {
+	if err := s.pool.SyncPosition(in.Pair, in.UserId); err != nil {
+		return &pb.SyncPositionReply{Result: &pb.ReplyResult{Code: 400, Msg: err.Error()}}, statusError(err)
+	}
+	return &pb.SyncPositionReply{Result: &pb.ReplyResult{Code: 0, Msg: "success"}}, nil
+}
+
+// AmendOrder 改单
+func (s *Server) AmendOrder(ctx context.Context, in *pb.AmendOrderRequest) (*pb.AmendOrderReply, error) {
+	price, amount := decimal.Zero, decimal.Zero
+	var err error
+	if in.Price != "" {
+		price, err = decimal.NewFromString(in.Price)
+		if err != nil {
+			return &pb.AmendOrderReply{Result: &pb.ReplyResult{Code: 400, Msg: "price error"}}, gstatus.Error(codes.InvalidArgument, "price error")
+		}
+	}
+	if in.Amount != "" {
+		amount, err = decimal.NewFromString(in.Amount)
+		if err != nil {
+			return &pb.AmendOrderReply{Result: &pb.ReplyResult{Code: 400, Msg: "amount error"}}, gstatus.Error(codes.InvalidArgument, "amount error")
+		}
+	}
+	trades, err := s.pool.AmendOrder(in.Pair, in.Id, price, amount)
+	if err != nil {
+		return &pb.AmendOrderReply{Result: &pb.ReplyResult{Code: 400, Msg: err.Error()}}, statusError(err)
+	}
+	return &pb.AmendOrderReply{
+		Result: &pb.ReplyResult{Code: 0, Msg: "success"},
+		Trades: toTrades(trades),
+	}, nil
+}
+
+// GetDepth 查询深度
+func (s *Server) GetDepth(ctx context.Context, in *pb.GetDepthRequest) (*pb.GetDepthReply, error) {
+	depth, err := s.pool.GetDepth(in.Pair, int(in.Levels))
+	if err != nil {
+		return &pb.GetDepthReply{Result: &pb.ReplyResult{Code: 400, Msg: err.Error()}}, statusError(err)
+	}
+	return &pb.GetDepthReply{
+		Result: &pb.ReplyResult{Code: 0, Msg: "success"},
+		Depth: &pb.Depth{
+			Pair: depth.Pair,
+			Bids: toDepthLevels(depth.Bids),
+			Asks: toDepthLevels(depth.Asks),
+			Ts:   depth.Ts,
+		},
+	}, nil
+}
+
+// statusError 撮合错误转换为gRPC状态码
+func statusError(err error) error {
+	code := codes.Internal
+	switch {
+	case errors.Is(err, match.ErrPair):
+		code = codes.NotFound
+	case errors.Is(err, match.ErrPairExists):
+		code = codes.AlreadyExists
+	case errors.Is(err, match.ErrHalted), errors.Is(err, match.ErrDelisted), errors.Is(err, match.ErrPhase),
+		errors.Is(err, match.ErrPosition):
+		code = codes.FailedPrecondition
+	case errors.Is(err, match.ErrOrderId), errors.Is(err, match.ErrOrderSide), errors.Is(err, match.ErrOrderType),
+		errors.Is(err, match.ErrOrderTimeInForce), errors.Is(err, match.ErrOrderPrice),
+		errors.Is(err, match.ErrOrderAmount), errors.Is(err, match.ErrTriggerPrice),
+		errors.Is(err, match.ErrPostOnly),
+		errors.Is(err, match.ErrDisplayAmount),
+		errors.Is(err, match.ErrExpireTime),
+		errors.Is(err, match.ErrQuoteAmount),
+		errors.Is(err, match.ErrProtectPrice),
+		errors.Is(err, match.ErrPriceTick), errors.Is(err, match.ErrPriceScale),
+		errors.Is(err, match.ErrAmountStep), errors.Is(err, match.ErrAmountScale),
+		errors.Is(err, match.ErrMinAmount), errors.Is(err, match.ErrMaxAmount),
+		errors.Is(err, match.ErrMinNotional), errors.Is(err, match.ErrMaxNotional),
+		errors.Is(err, match.ErrPairSpec),
+		errors.Is(err, match.ErrStp),
+		errors.Is(err, match.ErrTrailing),
+		errors.Is(err, match.ErrPeg),
+		errors.Is(err, match.ErrInvisible),
+		errors.Is(err, match.ErrMinQty), errors.Is(err, match.ErrAllOrNone),
+		errors.Is(err, match.ErrReduceOnly),
+		errors.Is(err, match.ErrOco),
+		errors.Is(err, match.ErrBracket):
+		code = codes.InvalidArgument
+	case errors.Is(err, match.ErrTimeout):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, match.ErrClosed):
+		code = codes.Unavailable
+	}
+	return gstatus.Error(code, err.Error())
+}
+
+// toOrder pb订单转换为撮合订单，数字字段格式错误时返回错误
+func toOrder(o *pb.Order) (*models.Order, error) {
+	price := decimal.Zero
+	var err error
+	if o.Price != "" || o.Peg == "" {
+		price, err = decimal.NewFromString(o.Price)
+		if err != nil {
+			return nil, errors.New("price error")
+		}
+	}
+	amount := decimal.Zero
+	if o.Amount != "" || o.QuoteAmount == "" && !o.ClosePosition { // 平仓订单的数量为空
+		amount, err = decimal.NewFromString(o.Amount)
+		if err != nil {
+			return nil, errors.New("amount error")
+		}
+	}
+	quoteAmount := decimal.Zero
+	if o.QuoteAmount != "" {
+		quoteAmount, err = decimal.NewFromString(o.QuoteAmount)
+		if err != nil {
+			return nil, errors.New("quote amount error")
+		}
+	}
+	triggerPrice := decimal.Zero
+	if o.TriggerPrice != "" {
+		triggerPrice, err = decimal.NewFromString(o.TriggerPrice)
+		if err != nil {
+			return nil, errors.New("trigger price error")
+		}
+	}
+	displayAmount := decimal.Zero
+	if o.DisplayAmount != "" {
+		displayAmount, err = decimal.NewFromString(o.DisplayAmount)
+		if err != nil {
+			return nil, errors.New("display amount error")
+		}
+	}
+	protectPrice := decimal.Zero
+	if o.ProtectPrice != "" {
+		protectPrice, err = decimal.NewFromString(o.ProtectPrice)
+		if err != nil {
+			return nil, errors.New("protect price error")
+		}
+	}
+	trailingAmount := decimal.Zero
+	if o.TrailingAmount != "" {
+		trailingAmount, err = decimal.NewFromString(o.TrailingAmount)
+		if err != nil {
+			return nil, errors.New("trailing amount error")
+		}
+	}
+	trailingPercent := decimal.Zero
+	if o.TrailingPercent != "" {
+		trailingPercent, err = decimal.NewFromString(o.TrailingPercent)
+		if err != nil {
+			return nil, errors.New("trailing percent error")
+		}
+	}
+	pegOffset := decimal.Zero
+	if o.PegOffset != "" {
+		pegOffset, err = decimal.NewFromString(o.PegOffset)
+		if err != nil {
+			return nil, errors.New("peg offset error")
+		}
+	}
+	pegLimit := decimal.Zero
+	if o.PegLimit != "" {
+		pegLimit, err = decimal.NewFromString(o.PegLimit)
+		if err != nil {
+			return nil, errors.New("peg limit error")
+		}
+	}
+	minQty := decimal.Zero
+	if o.MinQty != "" {
+		minQty, err = decimal.NewFromString(o.MinQty)
+		if err != nil {
+			return nil, errors.New("min qty error")
+		}
+	}
+	return &models.Order{
+		Id:           o.Id,
+		UserId:       o.UserId,
+		Pair:         o.Pair,
+		Price:        price,
+		Amount:       amount,
+		Side:         o.Side,
+		Type:         o.Type,
+		TimeInForce:  o.TimeInForce,
+		TriggerPrice: triggerPrice,
+		PostOnly:     o.PostOnly,
+		Stp:          o.Stp,
+
+		DisplayAmount: displayAmount,
+		ExpireTime:    o.ExpireTime,
+		QuoteAmount:   quoteAmount,
+		ProtectPrice:  protectPrice,
+
+		TrailingAmount:  trailingAmount,
+		TrailingPercent: trailingPercent,
+
+		Peg:       o.Peg,
+		PegOffset: pegOffset,
+		PegLimit:  pegLimit,
+		Invisible: o.Hidden,
+
+		MinQty:    minQty,
+		AllOrNone: o.AllOrNone,
+
+		ReduceOnly:    o.ReduceOnly,
+		ClosePosition: o.ClosePosition,
+	}, nil
+}
+
+// toTrades 成交单转换为pb结构
+func toTrades(trades []models.Trade) []*pb.Trade {
+	result := make([]*pb.Trade, 0, len(trades))
+	for _, t := range trades {
+		result = append(result, &pb.Trade{
+			Id:               t.Id,
+			Pair:             t.Pair,
+			MakerId:          t.MakerId,
+			TakerId:          t.TakerId,
+			MakerUser:        t.MakerUser,
+			TakerUser:        t.TakerUser,
+			Price:            t.Price,
+			Amount:           t.Amount,
+			TakerOrderSide:   t.TakerOrderSide,
+			TakerOrderType:   t.TakerOrderType,
+			TakerTimeInForce: t.TakerTimeInForce,
+			Ts:               t.Ts,
+			Reason:           t.Reason,
+		})
+	}
+	return result
+}
+
+// toDepthLevels 深度档位转换为pb结构
+func toDepthLevels(levels []models.DepthLevel) []*pb.DepthLevel {
+	result := make([]*pb.DepthLevel, 0, len(levels))
+	for _, l := range levels {
+		result = append(result, &pb.DepthLevel{
+			Price:  l.Price.String(),
+			Amount: l.Amount.String(),
+			Count:  l.Count,
+		})
+	}
+	return result
+}
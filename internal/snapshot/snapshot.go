@@ -0,0 +1,249 @@
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"hash/crc32"
+	"io"
+	"lightning-engine/models"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Version 当前快照格式版本，快照内容变化时递增。只读取当前版本的快照，
+// 其他版本的快照跳过，从更早的快照或日志恢复
+const Version = 1
+
+var (
+	ErrVersion  = errors.New("snapshot version not supported")
+	ErrChecksum = errors.New("snapshot checksum error")
+)
+
+// Header 快照文件头，占文件第一行
+type Header struct {
+	Version  int    `json:"v"`  // 快照格式版本
+	Pair     string `json:"P"`  // 交易对
+	Seq      int64  `json:"s"`  // 快照包含的最后一条日志序列号
+	Ts       int64  `json:"ts"` // 快照生成时间
+	Checksum uint32 `json:"c"`  // 文件头之后内容的crc32
+}
+
+// Snapshot 盘口快照，订单按价格优先、时间优先排列，按顺序插入即可还原盘口
+type Snapshot struct {
+	Header Header         `json:"-"`
+	Bids   []models.Order `json:"b"` // 买单，价格从高到低
+	Asks   []models.Order `json:"a"` // 卖单，价格从低到高
+
+	Stops     []models.Order  `json:"st"` // 止损单，同一方向按触发顺序排列
+	LastPrice decimal.Decimal `json:"lp"` // 最新成交价
+
+	Phase string `json:"ph,omitempty"` // 交易阶段，为空时为连续撮合
+
+	BreakerLows  []PricePoint `json:"bl,omitempty"` // 熔断窗口内的成交价单调递增队列
+	BreakerHighs []PricePoint `json:"bh,omitempty"` // 熔断窗口内的成交价单调递减队列
+	BreakerUntil int64        `json:"bu,omitempty"` // 熔断结束时间(毫秒时间戳)，0表示未熔断
+
+	Positions map[int64]decimal.Decimal `json:"ps,omitempty"` // 只减仓订单用户的持仓
+
+	Brackets []Bracket `json:"bk,omitempty"` // 父订单还在盘口中的括号订单，按父订单id排列
+}
+
+// PricePoint 成交时间和成交价
+type PricePoint struct {
+	Ts    int64           `json:"t"`
+	Price decimal.Decimal `json:"p"`
+}
+
+// Bracket 括号订单，父订单成交后激活止盈单和止损单
+type Bracket struct {
+	Parent string       `json:"pa"` // 父订单id
+	Profit models.Order `json:"pf"` // 止盈单
+	Stop   models.Order `json:"st"` // 止损单
+	Active bool         `json:"ac"` // 子订单是否已激活
+}
+
+// FileName 快照文件名
+func FileName(pair string, seq int64) string {
+	return fmt.Sprintf("%s.%020d.snapshot", strings.ReplaceAll(pair, "/", "_"), seq)
+}
+
+// Write 写入快照文件，先写临时文件再重命名，避免写入一半的快照被读取
+func Write(dir string, s *Snapshot) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	body, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	s.Header.Version = Version
+	s.Header.Checksum = crc32.ChecksumIEEE(body)
+	header, err := json.Marshal(s.Header)
+	if err != nil {
+		return "", err
+	}
+
+	name := filepath.Join(dir, FileName(s.Header.Pair, s.Header.Seq))
+	tmp := name + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return "", err
+	}
+	w := bufio.NewWriter(file)
+	w.Write(header)
+	w.WriteByte('\n')
+	w.Write(body)
+	if err := w.Flush(); err != nil {
+		file.Close()
+		return "", err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+	return name, os.Rename(tmp, name)
+}
+
+// Read 读取快照文件并校验checksum
+func Read(name string) (*Snapshot, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	i := bytes.IndexByte(b, '\n')
+	if i < 0 {
+		return nil, io.ErrUnexpectedEOF
+	}
+	s := &Snapshot{}
+	if err := json.Unmarshal(b[:i], &s.Header); err != nil {
+		return nil, err
+	}
+	if s.Header.Version != Version {
+		return nil, ErrVersion
+	}
+	body := b[i+1:]
+	if crc32.ChecksumIEEE(body) != s.Header.Checksum {
+		return nil, ErrChecksum
+	}
+	if err := json.Unmarshal(body, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Verify 校验快照内容：订单方向、数量、价格时间顺序以及订单id唯一
+func (s *Snapshot) Verify() error {
+	ids := make(map[string]struct{}, len(s.Bids)+len(s.Asks))
+	check := func(orders []models.Order, side string, sorted func(pre, cur models.Order) bool) error {
+		for i, o := range orders {
+			if o.Side != side {
+				return fmt.Errorf("order %s side error: %s", o.Id, o.Side)
+			}
+			if o.Pair != s.Header.Pair {
+				return fmt.Errorf("order %s pair error: %s", o.Id, o.Pair)
+			}
+			if !o.Amount.IsPositive() {
+				return fmt.Errorf("order %s amount error: %s", o.Id, o.Amount)
+			}
+			if i > 0 && !sorted(orders[i-1], o) {
+				return fmt.Errorf("order %s price order error: %s", o.Id, o.Price)
+			}
+			if _, ok := ids[o.Id]; ok {
+				return fmt.Errorf("order %s duplicated", o.Id)
+			}
+			ids[o.Id] = struct{}{}
+		}
+		return nil
+	}
+	err := check(s.Bids, models.Buy, func(pre, cur models.Order) bool {
+		return pre.Price.GreaterThanOrEqual(cur.Price)
+	})
+	if err != nil {
+		return err
+	}
+	err = check(s.Asks, models.Sell, func(pre, cur models.Order) bool {
+		return pre.Price.LessThanOrEqual(cur.Price)
+	})
+	if err != nil {
+		return err
+	}
+	for _, o := range s.Stops {
+		if !o.IsStop() || !o.TriggerPrice.IsPositive() || !o.Amount.IsPositive() {
+			return fmt.Errorf("stop order %s error", o.Id)
+		}
+		if _, ok := ids[o.Id]; ok {
+			return fmt.Errorf("order %s duplicated", o.Id)
+		}
+		ids[o.Id] = struct{}{}
+	}
+	return nil
+}
+
+// List 按序列号升序返回交易对所有快照的序列号
+func List(dir string, pair string) ([]int64, error) {
+	prefix := strings.ReplaceAll(pair, "/", "_") + "."
+	files, err := filepath.Glob(filepath.Join(dir, prefix+"*.snapshot"))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]int64, 0, len(files))
+	for _, f := range files {
+		seq, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(filepath.Base(f), prefix), ".snapshot"), 10, 64)
+		if err != nil {
+			continue
+		}
+		result = append(result, seq)
+	}
+	sort.Slice(result, func(a, b int) bool { return result[a] < result[b] })
+	return result, nil
+}
+
+// Latest 读取最新的可用快照，最新的快照损坏时依次尝试更早的快照，没有快照时返回nil
+func Latest(dir string, pair string) (*Snapshot, error) {
+	seqs, err := List(dir, pair)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(seqs) - 1; i >= 0; i-- {
+		name := filepath.Join(dir, FileName(pair, seqs[i]))
+		s, err := Read(name)
+		if err == nil {
+			err = s.Verify()
+		}
+		if err != nil {
+			log.Printf("skip snapshot %s: %v\n", name, err)
+			continue
+		}
+		return s, nil
+	}
+	return nil, nil
+}
+
+// Purge 只保留最新的keep个快照，返回保留的最早快照的序列号
+func Purge(dir string, pair string, keep int) (int64, error) {
+	seqs, err := List(dir, pair)
+	if err != nil || len(seqs) == 0 {
+		return 0, err
+	}
+	if keep < 1 {
+		keep = 1
+	}
+	for len(seqs) > keep {
+		if err := os.Remove(filepath.Join(dir, FileName(pair, seqs[0]))); err != nil {
+			return 0, err
+		}
+		seqs = seqs[1:]
+	}
+	return seqs[0], nil
+}
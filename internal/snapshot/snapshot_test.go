@@ -0,0 +1,91 @@
+package snapshot
+
+import (
+	"bytes"
+	"github.com/shopspring/decimal"
+	"lightning-engine/models"
+	"os"
+	"testing"
+)
+
+const pair = "BTC/USDT"
+
+func newSnapshot(seq int64) *Snapshot {
+	return &Snapshot{
+		Header: Header{Pair: pair, Seq: seq},
+		Bids: []models.Order{
+			{Id: "1", Pair: pair, Price: decimal.NewFromInt(100), Amount: decimal.NewFromInt(1), Side: models.Buy},
+			{Id: "2", Pair: pair, Price: decimal.NewFromInt(99), Amount: decimal.NewFromInt(1), Side: models.Buy},
+		},
+		Asks: []models.Order{
+			{Id: "3", Pair: pair, Price: decimal.NewFromInt(101), Amount: decimal.NewFromInt(1), Side: models.Sell},
+		},
+	}
+}
+
+func TestSnapshot_WriteRead(t *testing.T) {
+	dir := t.TempDir()
+	name, err := Write(dir, newSnapshot(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := Read(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Verify(); err != nil {
+		t.Error(err)
+	}
+	if s.Header.Seq != 10 || len(s.Bids) != 2 || len(s.Asks) != 1 {
+		t.Errorf("read error: %+v", s)
+	}
+
+	// 其他版本的快照不读取
+	b, _ := os.ReadFile(name)
+	os.WriteFile(name, bytes.Replace(b, []byte(`"v":1`), []byte(`"v":0`), 1), 0644)
+	if _, err := Read(name); err != ErrVersion {
+		t.Errorf("old version should be rejected: %v", err)
+	}
+	os.WriteFile(name, b, 0644)
+
+	// 篡改内容后checksum校验失败
+	b[len(b)-3] = '9'
+	os.WriteFile(name, b, 0644)
+	if _, err := Read(name); err == nil {
+		t.Error("checksum should fail")
+	}
+}
+
+func TestSnapshot_Verify(t *testing.T) {
+	s := newSnapshot(1)
+	s.Bids[0], s.Bids[1] = s.Bids[1], s.Bids[0]
+	if err := s.Verify(); err == nil {
+		t.Error("bid order should fail")
+	}
+	s = newSnapshot(1)
+	s.Asks[0].Id = "1"
+	if err := s.Verify(); err == nil {
+		t.Error("duplicated id should fail")
+	}
+}
+
+func TestSnapshot_LatestPurge(t *testing.T) {
+	dir := t.TempDir()
+	for _, seq := range []int64{5, 10, 15} {
+		if _, err := Write(dir, newSnapshot(seq)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s, err := Latest(dir, pair)
+	if err != nil || s == nil || s.Header.Seq != 15 {
+		t.Fatalf("latest error: %v %+v", err, s)
+	}
+	seq, err := Purge(dir, pair, 2)
+	if err != nil || seq != 10 {
+		t.Errorf("purge error: %v %d", err, seq)
+	}
+	seqs, _ := List(dir, pair)
+	if len(seqs) != 2 {
+		t.Errorf("list error: %v", seqs)
+	}
+}
@@ -24,7 +24,7 @@ func (h *SysSignalHandle) Begin() {
 	ss.register(syscall.SIGTERM, h.dealSysSignal)
 	ss.register(syscall.SIGQUIT, h.dealSysSignal)
 
-	c := make(chan os.Signal)
+	c := make(chan os.Signal, 1)
 	var sigs []os.Signal
 	for sig := range ss.m {
 		sigs = append(sigs, sig)
@@ -0,0 +1,18 @@
+package models
+
+import "github.com/shopspring/decimal"
+
+// Depth 盘口深度
+type Depth struct {
+	Pair string       `json:"P"`  // 交易对
+	Bids []DepthLevel `json:"b"`  // 买盘档位，价格从高到低
+	Asks []DepthLevel `json:"a"`  // 卖盘档位，价格从低到高
+	Ts   int64        `json:"ts"` // 深度生成时间
+}
+
+// DepthLevel 深度档位，同一价格的订单聚合为一档
+type DepthLevel struct {
+	Price  decimal.Decimal `json:"p"` // 价格
+	Amount decimal.Decimal `json:"a"` // 该价格下的总数量，冰山单只计算显示数量
+	Count  int64           `json:"c"` // 该价格下的订单数
+}
@@ -0,0 +1,31 @@
+package models
+
+// 盘口事件类型
+const (
+	EventCancelAll = "cancel_all" // 批量撤单汇总
+	EventPhase     = "phase"      // 交易阶段切换
+	EventAuction   = "auction"    // 集合竞价参考价
+)
+
+// 交易阶段
+const (
+	PhaseContinuous = "continuous" // 连续撮合
+	PhaseAuction    = "auction"    // 集合竞价，订单只挂单不撮合，结束时按同一价格成交
+	PhaseHalted     = "halted"     // 暂停交易，拒绝新订单，保留盘口
+	PhaseDelisted   = "delisted"   // 已下架，盘口订单全部撤销
+)
+
+// Event 盘口事件，不属于单个订单的事件通过IMQ.PushEvent推送
+type Event struct {
+	Id     string `json:"i"`  // 事件id
+	Pair   string `json:"P"`  // 交易对
+	Type   string `json:"t"`  // 事件类型
+	UserId int64  `json:"u"`  // 用户id，批量撤单时为过滤条件，0表示全部用户
+	Side   string `json:"s"`  // 订单方向，批量撤单时为过滤条件，为空表示买卖双方
+	Count  int64  `json:"c"`  // 批量撤单时撤销的订单数
+	Phase  string `json:"ph"` // 交易阶段切换后的阶段
+	Price  string `json:"p"`  // 集合竞价参考价，没有可成交的订单时为空
+	Amount string `json:"a"`  // 集合竞价参考价下可成交的数量
+	Reason string `json:"r"`  // 交易阶段切换的原因，为空时为管理接口切换
+	Ts     int64  `json:"ts"` // 事件时间
+}
@@ -8,13 +8,31 @@ const (
 	Buy  = "buy"
 	Sell = "sell"
 
-	Limit  = "limit"
-	Market = "market"
-	Cancel = "cancel"
+	Limit      = "limit"
+	Market     = "market"
+	StopMarket = "stop_market" // 止损市价单，最新成交价达到触发价后以市价单撮合
+	StopLimit  = "stop_limit"  // 止损限价单，最新成交价达到触发价后以限价单撮合
+	Cancel     = "cancel"
+	Trigger    = "trigger" // 止损单触发
+	Stp        = "stp"     // 自成交保护撤销
+	Amend      = "amend"   // 改单
+	Trail      = "trail"   // 跟踪止损单触发价更新
 
 	TimeInForceGTC = "GTC" // 订单一直有效，知道被成交或者取消
 	TimeInForceIOC = "IOC" // 无法立即成交的部分就撤销
 	TimeInForceFOK = "FOK" // 无法全部立即成交就撤销
+	TimeInForceGTD = "GTD" // 订单有效至ExpireTime，到期后自动撤销
+
+	PostOnlyReject  = "reject"  // 只做maker，会立即成交时拒绝
+	PostOnlyReprice = "reprice" // 只做maker，会立即成交时调整到对手价一个tick之外挂单
+
+	StpCancelNewest    = "cancel_newest"    // 自成交时撤销taker
+	StpCancelOldest    = "cancel_oldest"    // 自成交时撤销maker
+	StpCancelBoth      = "cancel_both"      // 自成交时撤销taker和maker
+	StpDecrementCancel = "decrement_cancel" // 自成交时双方减去较小的数量，数量为0的一方撤销
+
+	PegPrimary  = "primary"  // 挂钩同方向最优价，买单挂钩买一价，卖单挂钩卖一价
+	PegMidpoint = "midpoint" // 挂钩买一价和卖一价的中间价
 )
 
 // Order 订单, 实现INodeValue接口，存放在节点中
@@ -25,8 +43,84 @@ type Order struct {
 	Price       decimal.Decimal `json:"p"` // 价格
 	Amount      decimal.Decimal `json:"a"` // 数量
 	Side        string          `json:"s"` // 订单方向 buy/sell
-	Type        string          `json:"t"` // 订单类型 limit/market
-	TimeInForce string          `json:"f"` // 订单有效时间,type为limit时才生效 GTC/IOC/FOK
+	Type        string          `json:"t"` // 订单类型 limit/market/stop_market/stop_limit
+	TimeInForce string          `json:"f"` // 订单有效时间,type为limit/stop_limit时才生效 GTC/IOC/FOK/GTD
+
+	TriggerPrice decimal.Decimal `json:"tp"`  // 触发价，type为stop_market/stop_limit时才生效。买单最新成交价>=触发价时触发，卖单<=触发价时触发
+	PostOnly     string          `json:"po"`  // 只做maker reject/reprice，为空时不限制，GTC/GTD限价单才生效
+	Stp          string          `json:"stp"` // 自成交保护模式，为空时允许自成交
+
+	DisplayAmount decimal.Decimal `json:"da"` // 冰山单每次显示的数量，为0时不是冰山单，GTC/GTD限价单才生效
+	Hidden        decimal.Decimal `json:"h"`  // 冰山单隐藏的剩余数量，挂单时由撮合引擎拆分
+
+	ExpireTime int64 `json:"et"` // 过期时间(毫秒时间戳)，timeInForce为GTD时才生效
+
+	QuoteAmount  decimal.Decimal `json:"qa"` // 按计价货币金额下单的市价单金额，不为0时Amount必须为0
+	ProtectPrice decimal.Decimal `json:"pp"` // 市价单最差成交价，买单不高于、卖单不低于该价格，为0时不限制
+
+	LinkId string `json:"li,omitempty"` // OCO关联订单id，由撮合引擎设置，一方成交或撤销时撤销另一方
+
+	TrailingAmount  decimal.Decimal `json:"ta"` // 跟踪止损的固定价差，止损单才生效，与TrailingPercent只能设置一个
+	TrailingPercent decimal.Decimal `json:"tr"` // 跟踪止损的比例，0 < 比例 < 1
+	Watermark       decimal.Decimal `json:"wm"` // 跟踪止损挂单后的最高成交价(卖单)或最低成交价(买单)，由撮合引擎维护
+
+	Peg       string          `json:"pg,omitempty"` // 挂钩类型 primary/midpoint，为空时不是挂钩单，GTC/GTD限价单才生效，价格由撮合引擎维护
+	PegOffset decimal.Decimal `json:"pgo"`          // 挂钩价格偏移，买单为参考价减去偏移，卖单为参考价加上偏移
+	PegLimit  decimal.Decimal `json:"pgl"`          // 挂钩价格上限(买单)或下限(卖单)，为0时不限制
+
+	Invisible bool `json:"iv,omitempty"` // 隐藏单，不出现在深度中，同一价格排在显示的订单之后，GTC/GTD限价单才生效
+
+	MinQty    decimal.Decimal `json:"mq"`            // 最小成交数量，立即成交的数量不足时整单撤销，GTC/GTD/IOC限价单才生效，为0时不限制
+	AllOrNone bool            `json:"aon,omitempty"` // 全部成交，只在剩余数量可以一次全部成交时撮合，否则挂单等待，GTC/GTD限价单才生效
+
+	ReduceOnly    bool `json:"ro,omitempty"` // 只减仓，数量不超过持仓，持仓减少或反向时由撮合引擎减少或撤销
+	ClosePosition bool `json:"cp,omitempty"` // 平仓，市价单和止损市价单才生效，数量为空，由撮合引擎按持仓设置，同时为只减仓
+}
+
+// IsIceberg 是否为冰山单
+func (o *Order) IsIceberg() bool {
+	return o.DisplayAmount.IsPositive()
+}
+
+// TotalAmount 剩余总数量，冰山单包括隐藏数量
+func (o *Order) TotalAmount() decimal.Decimal {
+	return o.Amount.Add(o.Hidden)
+}
+
+// IsQuote 是否为按计价货币金额下单的市价单
+func (o *Order) IsQuote() bool {
+	return o.QuoteAmount.IsPositive()
+}
+
+// IsTrailing 是否为跟踪止损单，触发价由撮合引擎按最高/最低成交价计算
+func (o *Order) IsTrailing() bool {
+	return o.TrailingAmount.IsPositive() || o.TrailingPercent.IsPositive()
+}
+
+// MinFill 立即成交的最小数量，全部成交订单为剩余数量，为0时不限制
+func (o *Order) MinFill() decimal.Decimal {
+	if o.AllOrNone {
+		return o.Amount
+	}
+	return o.MinQty
+}
+
+// IsReduceOnly 是否为只减仓订单，平仓订单也是只减仓订单
+func (o *Order) IsReduceOnly() bool {
+	return o.ReduceOnly || o.ClosePosition
+}
+
+// IsStop 是否为止损单
+func (o *Order) IsStop() bool {
+	return o.Type == StopMarket || o.Type == StopLimit
+}
+
+// GetPriority 同一价格的排序优先级，隐藏单排在显示的订单之后
+func (o *Order) GetPriority() int {
+	if o.Invisible {
+		return 1
+	}
+	return 0
 }
 
 func (o *Order) GetId() string {
@@ -1,5 +1,24 @@
 package models
 
+// 撤销原因，TakerOrderType为cancel时有效
+const (
+	ReasonStopRejected = "stop_rejected" // 止损单到达时已满足触发条件，拒绝挂单
+	ReasonPostOnly     = "post_only"     // 只做maker订单会立即成交，拒绝挂单
+	ReasonCancelAll    = "cancel_all"    // 批量撤单
+	ReasonExpired      = "expired"       // GTD订单到期自动撤销
+	ReasonQuoteUnspent = "quote_unspent" // 按金额下单的市价单未花完的金额，Amount为计价货币金额
+	ReasonSlippage     = "slippage"      // 市价单超出价格保护范围或最差成交价的部分撤销
+	ReasonHalted       = "halted"        // 交易对暂停交易，拒绝挂单
+	ReasonDelisted     = "delisted"      // 交易对下架，撤销盘口订单或拒绝挂单
+	ReasonAuction      = "auction"       // 集合竞价阶段只接受GTC/GTD限价单和止损单
+	ReasonBreaker      = "breaker"       // 成交价触发熔断，剩余部分撤销；也是熔断时阶段切换事件的原因
+	ReasonOco          = "oco"           // OCO关联订单成交或撤销，撤销另一方
+	ReasonPeg          = "peg"           // 挂钩单按最优价重新定价；没有参考价时拒绝挂单
+	ReasonMinQty       = "min_qty"       // 可以立即成交的数量不足最小成交数量，整单撤销
+	ReasonReduceOnly   = "reduce_only"   // 只减仓订单超出持仓的部分撤销或减少数量；不能减仓时拒绝挂单
+	ReasonBracket      = "bracket"       // 括号订单的子订单按父订单成交数量增加数量；子订单结束后撤销父订单剩余部分
+)
+
 type Trade struct {
 	Id               string `json:"i"`  // 成交单id
 	Pair             string `json:"P"`  // 交易对
@@ -10,7 +29,13 @@ type Trade struct {
 	Price            string `json:"p"`  // 成交价
 	Amount           string `json:"a"`  // 成交数量
 	TakerOrderSide   string `json:"s"`  // taker订单方向 buy/sell
-	TakerOrderType   string `json:"t"`  // taker订单类型 limit/market/cancel
+	TakerOrderType   string `json:"t"`  // taker订单类型 limit/market/cancel/trigger/stp/amend
 	TakerTimeInForce string `json:"f"`  // taker订单有效时间,type为limit时才生效 GTC/IOC/FOK
 	Ts               int64  `json:"ts"` // 成交时间
+	Reason           string `json:"r"`  // 撤销原因，为空时表示用户撤单或未成交部分撤销，stp时为自成交保护模式
+}
+
+// IsFill 是否为真实成交，撤销、触发等事件返回false
+func (t *Trade) IsFill() bool {
+	return t.TakerOrderType == Limit || t.TakerOrderType == Market
 }
@@ -8,4 +8,5 @@ import "lightning-engine/models"
 // 需根据对应项目使用的消息队列，编写对应的实现类。
 type IMQ interface {
 	PushTrade(...models.Trade) // 推送成交单。注：成交单包括已取消的委托单，需要特殊处理。
+	PushEvent(...models.Event) // 推送盘口事件，如批量撤单汇总等。
 }
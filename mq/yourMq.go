@@ -16,3 +16,8 @@ func (mq *YourMq) PushTrade(trades ...models.Trade) {
 	// 根据自己使用的队列，实现IMQ接口相应的方法
 	log.Printf("成交单： %+v\n", trades)
 }
+
+func (mq *YourMq) PushEvent(events ...models.Event) {
+	// 根据自己使用的队列，实现IMQ接口相应的方法
+	log.Printf("盘口事件： %+v\n", events)
+}
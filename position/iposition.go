@@ -0,0 +1,11 @@
+package position
+
+import "github.com/shopspring/decimal"
+
+// IPosition
+// 持仓接口，衍生品交易对的只减仓订单需要查询用户持仓，撮合引擎本身不维护持仓。
+// 返回的持仓需包含撮合引擎已推送的成交，查询之后的成交由撮合引擎自行累计。
+// 需根据对应项目的持仓服务，编写对应的实现类。
+type IPosition interface {
+	GetPosition(pair string, userId int64) decimal.Decimal // 查询用户在交易对的持仓，多仓为正数，空仓为负数，没有持仓为0
+}
@@ -0,0 +1,5 @@
+package position
+
+import "github.com/google/wire"
+
+var ProviderSet = wire.NewSet(NewYourPosition)
@@ -0,0 +1,21 @@
+package position
+
+import (
+	"github.com/shopspring/decimal"
+	"log"
+)
+
+type YourPosition struct {
+}
+
+// NewYourPosition 未接入持仓服务时返回nil，撮合引擎不接受只减仓和平仓订单(ErrPosition)；
+// 实现GetPosition后返回&YourPosition{}开启只减仓订单
+func NewYourPosition() IPosition {
+	return nil
+}
+
+func (p *YourPosition) GetPosition(pair string, userId int64) decimal.Decimal {
+	// 根据自己的持仓服务，实现IPosition接口相应的方法
+	log.Printf("查询持仓： %s %d\n", pair, userId)
+	return decimal.Zero
+}
@@ -64,6 +64,7 @@ func MaxLevel(maxLevel int) Option {
 func (list *SkipList) Insert(score decimal.Decimal, value pqueue.INodeValue) *SkipListNode {
 	rank := make([]int64, list.maxLevel)           // 新增节点每一层上是第几个节点
 	update := make([]*SkipListNode, list.maxLevel) // 新增节点每一层的上一个节点
+	priority := priorityOf(value)
 	p := list.head
 	for i := list.level - 1; i >= 0; i-- {
 		if i == list.level-1 {
@@ -71,8 +72,8 @@ func (list *SkipList) Insert(score decimal.Decimal, value pqueue.INodeValue) *Sk
 		} else {
 			rank[i] = rank[i+1]
 		}
-		// 下个节点存在，并且下个节点的score小于等于score时(score相同，按时间排序)
-		for p.Next(i) != nil && p.Next(i).score.LessThanOrEqual(score) {
+		// 下个节点存在，并且下个节点的score小于score，或score相同且优先级不低于新节点时(score和优先级相同，按时间排序)
+		for p.Next(i) != nil && (p.Next(i).score.LessThan(score) || p.Next(i).score.Equal(score) && p.Next(i).priority <= priority) {
 			rank[i] += p.level[i].span
 			p = p.Next(i)
 		}
@@ -24,6 +24,7 @@ func NewSkipListDesc(options ...Option) (*SkipListDesc, error) {
 func (list *SkipListDesc) Insert(score decimal.Decimal, value pqueue.INodeValue) *SkipListNode {
 	rank := make([]int64, list.maxLevel)           // 新增节点每一层上是第几个节点
 	update := make([]*SkipListNode, list.maxLevel) // 新增节点每一层的上一个节点
+	priority := priorityOf(value)
 	p := list.head
 	for i := list.level - 1; i >= 0; i-- {
 		if i == list.level-1 {
@@ -31,8 +32,8 @@ func (list *SkipListDesc) Insert(score decimal.Decimal, value pqueue.INodeValue)
 		} else {
 			rank[i] = rank[i+1]
 		}
-		// 下个节点存在，并且下个节点的score大于等于score时(score相同，按时间排序)
-		for p.Next(i) != nil && p.Next(i).score.GreaterThanOrEqual(score) {
+		// 下个节点存在，并且下个节点的score大于score，或score相同且优先级不低于新节点时(score和优先级相同，按时间排序)
+		for p.Next(i) != nil && (p.Next(i).score.GreaterThan(score) || p.Next(i).score.Equal(score) && p.Next(i).priority <= priority) {
 			rank[i] += p.level[i].span
 			p = p.Next(i)
 		}
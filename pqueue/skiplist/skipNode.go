@@ -21,6 +21,8 @@ type SkipListNode struct {
 	value pqueue.INodeValue
 	// 用于排序，使用高精度
 	score decimal.Decimal
+	// score相同时的排序优先级，越小越靠前
+	priority int
 }
 
 func NewSkipListNode(level int, score decimal.Decimal, value pqueue.INodeValue) *SkipListNode {
@@ -29,9 +31,18 @@ func NewSkipListNode(level int, score decimal.Decimal, value pqueue.INodeValue)
 		level:    make([]SkipListLevel, level),
 		value:    value,
 		score:    score,
+		priority: priorityOf(value),
 	}
 }
 
+// priorityOf 节点值的优先级，未实现IPriority时为0
+func priorityOf(value pqueue.INodeValue) int {
+	if p, ok := value.(pqueue.IPriority); ok {
+		return p.GetPriority()
+	}
+	return 0
+}
+
 // Next 第i层的下个元素
 func (node *SkipListNode) Next(i int) *SkipListNode {
 	return node.level[i].forward
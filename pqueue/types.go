@@ -13,3 +13,9 @@ type INodeValue interface {
 	// SetAmount 更新节点值的数量
 	SetAmount(decimal.Decimal)
 }
+
+// IPriority 节点值可选实现的优先级，score相同时优先级小的排在前面，优先级相同时按插入时间排序。
+// 未实现该接口的节点值优先级为0
+type IPriority interface {
+	GetPriority() int
+}
@@ -51,3 +51,25 @@ func TestCancel(t *testing.T) {
 	reply, err := client.CancelOrder(context.Background(), req)
 	fmt.Println(reply, err)
 }
+func TestDepth(t *testing.T) {
+	req := &pb.GetDepthRequest{
+		Pair:   "BTC-USDT",
+		Levels: 20,
+	}
+	reply, err := client.GetDepth(context.Background(), req)
+	fmt.Println(reply, err)
+}
+func TestAddSync(t *testing.T) {
+	req := &pb.AddOrderRequest{Order: &pb.Order{
+		Id:          "3",
+		UserId:      2,
+		Pair:        "BTC-USDT",
+		Price:       "21000",
+		Amount:      "1",
+		Side:        "sell",
+		Type:        "limit",
+		TimeInForce: "IOC",
+	}, Sync: true}
+	reply, err := client.AddOrder(context.Background(), req)
+	fmt.Println(reply, err)
+}